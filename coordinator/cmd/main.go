@@ -2,21 +2,40 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/oxia-io/okk/coordinator/internal/api"
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
 	"github.com/oxia-io/okk/coordinator/internal/task"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var (
-	listenAddr string
+	listenAddr        string
+	grpcListenAddr    string
+	registryTokenFile string
+	workerAuth        config.WorkerAuth
+
+	tlsCertFile     string
+	tlsKeyFile      string
+	tlsClientCAFile string
+	apiTokensFile   string
+	apiCertRoles    map[string]string
+	publicHealthz   bool
+	publicMetrics   bool
 )
 
 func main() {
@@ -27,6 +46,21 @@ func main() {
 	}
 
 	rootCmd.Flags().StringVar(&listenAddr, "listen", ":8080", "HTTP listen address")
+	rootCmd.Flags().StringVar(&grpcListenAddr, "grpc-listen", ":8081", "gRPC listen address of the worker registry and the admin API, empty to disable them")
+	rootCmd.Flags().StringVar(&registryTokenFile, "registry-token-file", "", "File holding the bearer token workers must present to register")
+	rootCmd.Flags().StringVar(&tlsCertFile, "tls-cert", "", "Certificate served by the HTTP API, which is plaintext without one")
+	rootCmd.Flags().StringVar(&tlsKeyFile, "tls-key", "", "Key of the HTTP API certificate")
+	rootCmd.Flags().StringVar(&tlsClientCAFile, "tls-client-ca", "", "CA verifying the client certificates presented to the HTTP API")
+	rootCmd.Flags().StringVar(&apiTokensFile, "api-tokens-file", "", "File of '<token> <role> [<name>]' lines accepted as bearer tokens by the HTTP API")
+	rootCmd.Flags().StringToStringVar(&apiCertRoles, "api-cert-roles", nil, "Roles granted to client certificates by common name, e.g. ci=operator,grafana=reader")
+	rootCmd.Flags().BoolVar(&publicHealthz, "public-healthz", true, "Serve /healthz without authentication")
+	rootCmd.Flags().BoolVar(&publicMetrics, "public-metrics", true, "Serve /metrics without authentication")
+	rootCmd.Flags().BoolVar(&workerAuth.TLS, "worker-tls", false, "Connect to workers with TLS, implied by the other worker-tls flags")
+	rootCmd.Flags().StringVar(&workerAuth.CAFile, "worker-tls-ca", "", "CA certificate verifying the workers, the system roots by default")
+	rootCmd.Flags().StringVar(&workerAuth.CertFile, "worker-tls-cert", "", "Client certificate presented to the workers for mutual TLS")
+	rootCmd.Flags().StringVar(&workerAuth.KeyFile, "worker-tls-key", "", "Key of the client certificate")
+	rootCmd.Flags().StringVar(&workerAuth.ServerName, "worker-tls-server-name", "", "Name the worker certificates are verified against")
+	rootCmd.Flags().StringVar(&workerAuth.TokenFile, "worker-token-file", "", "File holding a bearer token sent to the workers")
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -39,12 +73,53 @@ func run(_ *cobra.Command, _ []string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	manager := task.NewManager(ctx)
-	server := api.NewServer(manager)
+	var defaultWorkerAuth *config.WorkerAuth
+	if workerAuth.TLSEnabled() || workerAuth.TokenFile != "" {
+		if err := task.ValidateWorkerAuth(&workerAuth); err != nil {
+			return err
+		}
+		defaultWorkerAuth = &workerAuth
+	}
+
+	auth, err := newAuth()
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := newTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	manager := task.NewManager(ctx, defaultWorkerAuth)
+	defer func() {
+		if err := manager.Close(); err != nil {
+			slog.Error("Manager shutdown error", "error", err)
+		}
+	}()
+	server := api.NewServer(manager, auth)
+
+	grpcServer, err := newGRPCServer(manager, auth, tlsConfig)
+	if err != nil {
+		return err
+	}
+	if grpcServer != nil {
+		listener, err := net.Listen("tcp", grpcListenAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", grpcListenAddr, err)
+		}
+		defer grpcServer.GracefulStop()
+		go func() {
+			slog.Info(fmt.Sprintf("gRPC server listening on %s", grpcListenAddr), "tls", tlsConfig != nil, "auth", auth != nil)
+			if err := grpcServer.Serve(listener); err != nil {
+				slog.Error("gRPC server error", "error", err)
+			}
+		}()
+	}
 
 	httpServer := &http.Server{
-		Addr:    listenAddr,
-		Handler: server.Handler(),
+		Addr:      listenAddr,
+		Handler:   server.Handler(),
+		TLSConfig: tlsConfig,
 	}
 
 	// Graceful shutdown
@@ -63,10 +138,113 @@ func run(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
-	slog.Info(fmt.Sprintf("HTTP server listening on %s", listenAddr))
-	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	slog.Info(fmt.Sprintf("HTTP server listening on %s", listenAddr), "tls", tlsConfig != nil, "auth", auth != nil)
+	if tlsConfig != nil {
+		err = httpServer.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("HTTP server error: %w", err)
 	}
 
 	return nil
 }
+
+// newAuth returns the authentication of the HTTP API, or nil when neither
+// tokens nor client certificate roles are configured.
+func newAuth() (*api.Auth, error) {
+	auth := &api.Auth{
+		PublicHealthz: publicHealthz,
+		PublicMetrics: publicMetrics,
+	}
+	if apiTokensFile != "" {
+		tokens, err := api.LoadTokenAuthenticator(apiTokensFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the API tokens: %w", err)
+		}
+		auth.Authenticators = append(auth.Authenticators, tokens)
+	}
+	if len(apiCertRoles) > 0 {
+		if tlsClientCAFile == "" {
+			return nil, fmt.Errorf("--api-cert-roles requires --tls-client-ca")
+		}
+		certs := &api.CertAuthenticator{Roles: make(map[string]api.Role, len(apiCertRoles))}
+		for commonName, value := range apiCertRoles {
+			role, err := api.ParseRole(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid role of %s: %w", commonName, err)
+			}
+			certs.Roles[commonName] = role
+		}
+		auth.Authenticators = append(auth.Authenticators, certs)
+	}
+	if len(auth.Authenticators) == 0 {
+		slog.Warn("The HTTP API is not authenticated, anyone reaching it can create and delete testcases")
+		return nil, nil
+	}
+	return auth, nil
+}
+
+// newGRPCServer returns the gRPC server of the worker registry and the admin
+// API, sharing the TLS configuration and the authentication of the HTTP API,
+// or nil when it is disabled.
+func newGRPCServer(manager *task.Manager, auth *api.Auth, tlsConfig *tls.Config) (*grpc.Server, error) {
+	if grpcListenAddr == "" {
+		return nil, nil
+	}
+	var options []grpc.ServerOption
+	if tlsConfig != nil {
+		serverTLSConfig := tlsConfig.Clone()
+		certificate, err := tls.LoadX509KeyPair(tlsCertFile, tlsKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS certificate: %w", err)
+		}
+		serverTLSConfig.Certificates = []tls.Certificate{certificate}
+		options = append(options, grpc.Creds(credentials.NewTLS(serverTLSConfig)))
+	}
+	if registryTokenFile != "" {
+		content, err := os.ReadFile(registryTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the registry token: %w", err)
+		}
+		token := strings.TrimSpace(string(content))
+		if token == "" {
+			return nil, fmt.Errorf("%s is empty", registryTokenFile)
+		}
+		options = append(options, grpc.UnaryInterceptor(api.RegistryTokenInterceptor(token)))
+	} else {
+		slog.Warn("The worker registry is not authenticated, anyone reaching it can register workers")
+	}
+	grpcServer := grpc.NewServer(options...)
+	proto.RegisterOkkRegistryServer(grpcServer, api.NewRegistryService(manager.Registry()))
+	proto.RegisterOkkAdminServer(grpcServer, api.NewAdminService(manager, auth))
+	return grpcServer, nil
+}
+
+// newTLSConfig returns the TLS configuration of the HTTP API, or nil to serve it in plaintext.
+func newTLSConfig() (*tls.Config, error) {
+	if tlsCertFile == "" && tlsKeyFile == "" {
+		if tlsClientCAFile != "" {
+			return nil, fmt.Errorf("--tls-client-ca requires --tls-cert and --tls-key")
+		}
+		return nil, nil
+	}
+	if tlsCertFile == "" || tlsKeyFile == "" {
+		return nil, fmt.Errorf("--tls-cert and --tls-key must be set together")
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if tlsClientCAFile != "" {
+		pem, err := os.ReadFile(tlsClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client CA: %w", err)
+		}
+		tlsConfig.ClientCAs = x509.NewCertPool()
+		if !tlsConfig.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", tlsClientCAFile)
+		}
+		// bearer tokens still authenticate the clients without a certificate
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsConfig, nil
+}
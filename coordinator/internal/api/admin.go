@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/oxia-io/okk/coordinator/internal/task"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchInterval is how often WatchTestCase checks the status for changes.
+const watchInterval = time.Second
+
+// AdminService serves the OkkAdmin gRPC service, the typed counterpart of the
+// HTTP testcase endpoints.
+type AdminService struct {
+	proto.UnimplementedOkkAdminServer
+	manager *task.Manager
+	auth    *Auth
+}
+
+// NewAdminService returns an AdminService protected by auth, or serving
+// everyone when auth is nil.
+func NewAdminService(manager *task.Manager, auth *Auth) *AdminService {
+	return &AdminService{manager: manager, auth: auth}
+}
+
+func (s *AdminService) CreateTestCase(ctx context.Context, req *proto.CreateTestCaseRequest) (*proto.TestCaseStatus, error) {
+	principal, err := s.auth.authorizeRPC(ctx, RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+	if req.TestCase == nil {
+		return nil, status.Error(codes.InvalidArgument, "test_case is required")
+	}
+	tc := testCaseFromProto(req.TestCase)
+	if err := validateTestCase(tc); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.manager.CreateTask(tc); err != nil {
+		if errors.Is(err, task.ErrInvalidTestCase) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	slog.Info("Testcase created", "name", tc.Name, "type", tc.Type, "by", principal)
+	return s.getStatus(tc.Name)
+}
+
+func (s *AdminService) ListTestCases(ctx context.Context, _ *proto.ListTestCasesRequest) (*proto.ListTestCasesResponse, error) {
+	if _, err := s.auth.authorizeRPC(ctx, RoleReader); err != nil {
+		return nil, err
+	}
+	statuses := s.manager.ListStatuses()
+	response := &proto.ListTestCasesResponse{TestCases: make([]*proto.TestCaseStatus, 0, len(statuses))}
+	for _, st := range statuses {
+		response.TestCases = append(response.TestCases, statusToProto(st))
+	}
+	return response, nil
+}
+
+func (s *AdminService) GetTestCase(ctx context.Context, req *proto.GetTestCaseRequest) (*proto.TestCaseStatus, error) {
+	if _, err := s.auth.authorizeRPC(ctx, RoleReader); err != nil {
+		return nil, err
+	}
+	return s.getStatus(req.Name)
+}
+
+func (s *AdminService) DeleteTestCase(ctx context.Context, req *proto.DeleteTestCaseRequest) (*proto.DeleteTestCaseResponse, error) {
+	principal, err := s.auth.authorizeRPC(ctx, RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.manager.DeleteTask(req.Name); err != nil {
+		if errors.Is(err, task.ErrSuiteMember) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	slog.Info("Testcase deleted", "name", req.Name, "by", principal)
+	return &proto.DeleteTestCaseResponse{}, nil
+}
+
+func (s *AdminService) WatchTestCase(req *proto.WatchTestCaseRequest, stream grpc.ServerStreamingServer[proto.TestCaseStatus]) error {
+	if _, err := s.auth.authorizeRPC(stream.Context(), RoleReader); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var last *proto.TestCaseStatus
+	for {
+		st, found := s.manager.GetStatus(req.Name)
+		if !found {
+			if last == nil {
+				return status.Errorf(codes.NotFound, "testcase not found: %s", req.Name)
+			}
+			return status.Errorf(codes.NotFound, "testcase deleted: %s", req.Name)
+		}
+		current := statusToProto(st)
+		if !current.EqualVT(last) {
+			if err := stream.Send(current); err != nil {
+				return err
+			}
+			last = current
+		}
+		if current.State != task.StateRunning {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AdminService) getStatus(name string) (*proto.TestCaseStatus, error) {
+	st, found := s.manager.GetStatus(name)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "testcase not found: %s", name)
+	}
+	return statusToProto(st), nil
+}
+
+func testCaseFromProto(tc *proto.TestCase) *config.TestCaseConfig {
+	result := &config.TestCaseConfig{
+		Name:           tc.Name,
+		Type:           tc.Type,
+		Namespace:      tc.Namespace,
+		WorkerEndpoint: tc.WorkerEndpoint,
+		WorkerSelector: tc.WorkerSelector,
+		OpRate:         int(tc.OpRate),
+		Duration:       tc.Duration,
+		AssertionMode:  tc.AssertionMode,
+		OpTimeout:      tc.OpTimeout,
+		OpTimeouts:     tc.OpTimeouts,
+		Properties:     tc.Properties,
+	}
+	if policy := tc.FailurePolicy; policy != nil {
+		result.FailurePolicy = &config.FailurePolicy{
+			MaxRetries:                 policy.MaxRetries,
+			MaxElapsed:                 policy.MaxElapsed,
+			ContinueOnAssertionFailure: policy.ContinueOnAssertionFailure,
+			ResyncOnAssertionFailure:   policy.ResyncOnAssertionFailure,
+			StopOnNonRetryable:         policy.StopOnNonRetryable,
+		}
+	}
+	if auth := tc.WorkerAuth; auth != nil {
+		result.WorkerAuth = &config.WorkerAuth{
+			TLS:        auth.Tls,
+			CAFile:     auth.CaFile,
+			CertFile:   auth.CertFile,
+			KeyFile:    auth.KeyFile,
+			ServerName: auth.ServerName,
+			TokenFile:  auth.TokenFile,
+		}
+	}
+	return result
+}
+
+func statusToProto(st *task.TaskStatus) *proto.TestCaseStatus {
+	result := &proto.TestCaseStatus{
+		Name:                 st.Name,
+		Type:                 st.Type,
+		Namespace:            st.Namespace,
+		WorkerEndpoint:       st.WorkerEndpoint,
+		State:                st.State,
+		StateReason:          st.StateReason,
+		Phase:                st.Phase,
+		Operations:           st.Operations,
+		AssertionsPassed:     st.AssertionsPassed,
+		AssertionsFailed:     st.AssertionsFailed,
+		Timeouts:             st.Timeouts,
+		Retries:              st.Retries,
+		NonRetryableFailures: st.NonRetryable,
+		Resyncs:              st.Resyncs,
+		LastFailure:          st.LastFailure,
+		Failures:             make([]*proto.TestCaseFailure, 0, len(st.Failures)),
+	}
+	if st.RunningSince != nil {
+		runningSince := st.RunningSince.UnixNano()
+		result.RunningSince = &runningSince
+	}
+	for _, failure := range st.Failures {
+		result.Failures = append(result.Failures, failureToProto(failure))
+	}
+	return result
+}
+
+func failureToProto(failure *task.AssertionFailure) *proto.TestCaseFailure {
+	result := &proto.TestCaseFailure{
+		Sequence:             failure.Sequence,
+		Key:                  failure.Key,
+		Message:              failure.Message,
+		ExpectedRecords:      failedRecordsToProto(failure.ExpectedRecords),
+		ActualRecords:        failedRecordsToProto(failure.ActualRecords),
+		ExpectedNotification: failure.ExpectedNotification,
+		ActualNotification:   failure.ActualNotification,
+		ExpectedError:        failure.ExpectedError,
+		ActualError:          failure.ActualError,
+		ObservedTimestamp:    failure.ObservedTime.UnixNano(),
+		Worker:               failure.Worker,
+	}
+	if diff := failure.Diff; diff != nil {
+		result.MissingKeys = diff.MissingKeys
+		result.ExtraKeys = diff.ExtraKeys
+		result.ValueMismatches = diff.ValueMismatches
+		result.Mismatches = diff.Mismatches
+	}
+	if failure.OperationTime != nil {
+		operationTimestamp := failure.OperationTime.UnixNano()
+		result.OperationTimestamp = &operationTimestamp
+	}
+	return result
+}
+
+func failedRecordsToProto(records []task.FailedRecord) []*proto.FailedRecord {
+	result := make([]*proto.FailedRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, &proto.FailedRecord{
+			Key:           record.Key,
+			Value:         record.Value,
+			ValueChecksum: record.ValueChecksum,
+			ValueLength:   record.ValueLength,
+		})
+	}
+	return result
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Role is what an authenticated caller is allowed to do. An operator can do
+// everything a reader can.
+type Role string
+
+const (
+	// RoleReader can read the testcases.
+	RoleReader Role = "reader"
+	// RoleOperator can also create and delete testcases.
+	RoleOperator Role = "operator"
+)
+
+func ParseRole(value string) (Role, error) {
+	switch role := Role(value); role {
+	case RoleReader, RoleOperator:
+		return role, nil
+	default:
+		return "", fmt.Errorf("unknown role %q, expected %s or %s", value, RoleReader, RoleOperator)
+	}
+}
+
+func (r Role) allows(required Role) bool {
+	return r == RoleOperator || r == required
+}
+
+// Principal is an authenticated caller.
+type Principal struct {
+	Name string
+	Role Role
+}
+
+// Authenticator identifies the caller of a request. It returns false when
+// the request carries no credentials it recognizes, so that the next
+// Authenticator can be tried.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, bool)
+}
+
+// Auth protects the routes of a Server. Each request is authenticated by the
+// first Authenticator recognizing it, and the healthz and metrics routes can
+// be left public, e.g. for probes and scrapers.
+type Auth struct {
+	Authenticators []Authenticator
+	PublicHealthz  bool
+	PublicMetrics  bool
+}
+
+func (a *Auth) authenticate(r *http.Request) (*Principal, bool) {
+	for _, authenticator := range a.Authenticators {
+		if principal, ok := authenticator.Authenticate(r); ok {
+			return principal, true
+		}
+	}
+	return nil, false
+}
+
+var _ Authenticator = &TokenAuthenticator{}
+
+type tokenEntry struct {
+	token     []byte
+	principal *Principal
+}
+
+// TokenAuthenticator accepts static bearer tokens.
+type TokenAuthenticator struct {
+	tokens []tokenEntry
+}
+
+func (t *TokenAuthenticator) Authenticate(r *http.Request) (*Principal, bool) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		return nil, false
+	}
+	var principal *Principal
+	// compare with every token, so that the time taken does not tell which one matched
+	for _, entry := range t.tokens {
+		if subtle.ConstantTimeCompare(entry.token, []byte(token)) == 1 {
+			principal = entry.principal
+		}
+	}
+	return principal, principal != nil
+}
+
+// LoadTokenAuthenticator reads the tokens from a file holding one
+// "<token> <role> [<name>]" entry per line. Empty lines and lines starting
+// with # are ignored.
+func LoadTokenAuthenticator(path string) (*TokenAuthenticator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	authenticator := &TokenAuthenticator{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%s:%d: expected <token> <role> [<name>]", path, line)
+		}
+		role, err := ParseRole(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		name := fmt.Sprintf("token-%d", line)
+		if len(fields) == 3 {
+			name = fields[2]
+		}
+		authenticator.tokens = append(authenticator.tokens, tokenEntry{
+			token:     []byte(fields[0]),
+			principal: &Principal{Name: name, Role: role},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(authenticator.tokens) == 0 {
+		return nil, fmt.Errorf("%s: no token found", path)
+	}
+	return authenticator, nil
+}
+
+var _ Authenticator = &CertAuthenticator{}
+
+// CertAuthenticator accepts the client certificates verified by the TLS
+// listener, granting the role mapped to their common name.
+type CertAuthenticator struct {
+	Roles map[string]Role
+}
+
+func (c *CertAuthenticator) Authenticate(r *http.Request) (*Principal, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return nil, false
+	}
+	commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+	role, found := c.Roles[commonName]
+	if !found {
+		return nil, false
+	}
+	return &Principal{Name: commonName, Role: role}, true
+}
+
+// authorize wraps handler so that it is only served to callers with role,
+// or to everyone when auth is nil or public is set.
+func (s *Server) authorize(role Role, public bool, handler http.Handler) http.Handler {
+	if s.auth == nil || public {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := s.auth.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		if !principal.Role.allows(role) {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("%s is not allowed to %s %s", principal.Name, r.Method, r.URL.Path))
+			return
+		}
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+// authorizeRPC is the gRPC counterpart of authorize. The bearer token and
+// the client certificate of the call are handed to the Authenticators as an
+// HTTP request. It returns the name of the caller, for audit logs.
+func (a *Auth) authorizeRPC(ctx context.Context, role Role) (string, error) {
+	if a == nil {
+		return "anonymous", nil
+	}
+	r := (&http.Request{Header: http.Header{}}).WithContext(ctx)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, value := range md.Get("authorization") {
+			r.Header.Add("Authorization", value)
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			r.TLS = &info.State
+		}
+	}
+	principal, ok := a.authenticate(r)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !principal.Role.allows(role) {
+		return "", status.Errorf(codes.PermissionDenied, "%s is not allowed to %s", principal.Name, grpcMethod(ctx))
+	}
+	return principal.Name, nil
+}
+
+func grpcMethod(ctx context.Context) string {
+	if method, ok := grpc.Method(ctx); ok {
+		return method
+	}
+	return "call this method"
+}
+
+type principalKey struct{}
+
+// principalName returns the name of the caller of an authorized request, for audit logs.
+func principalName(r *http.Request) string {
+	if principal, ok := r.Context().Value(principalKey{}).(*Principal); ok {
+		return principal.Name
+	}
+	return "anonymous"
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// keepAliveInterval is how often an idle event stream sends a comment, so
+// that proxies do not close it.
+const keepAliveInterval = 15 * time.Second
+
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	s.serveEvents(w, r, "")
+}
+
+func (s *Server) streamTestCaseEvents(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if _, found := s.manager.GetStatus(name); !found {
+		writeError(w, http.StatusNotFound, "testcase not found: "+name)
+		return
+	}
+	s.serveEvents(w, r, name)
+}
+
+// serveEvents streams the events of testcase, or of every testcase when it
+// is empty, as server-sent events. A client reconnecting with the
+// Last-Event-ID header gets the events it missed first, as long as they are
+// still kept.
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request, testcase string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+	var afterID uint64
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid Last-Event-ID: "+lastEventID)
+			return
+		}
+		afterID = id
+	}
+
+	events, unsubscribe := s.manager.Events().Subscribe(testcase, afterID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				// dropped for falling behind, or shutting down
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				slog.Error("Failed to encode event", "id", event.ID, "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/oxia-io/okk/coordinator/internal/task"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RegistryService serves the OkkRegistry gRPC service the workers register through.
+type RegistryService struct {
+	proto.UnimplementedOkkRegistryServer
+	registry *task.Registry
+}
+
+func (s *RegistryService) Register(_ context.Context, req *proto.RegisterRequest) (*proto.RegisterResponse, error) {
+	if req.Endpoint == "" {
+		return nil, status.Error(codes.InvalidArgument, "endpoint is required")
+	}
+	worker := s.registry.Register(req.Endpoint, req.Labels)
+	return &proto.RegisterResponse{
+		WorkerId:                worker.ID,
+		HeartbeatIntervalMillis: s.registry.HeartbeatInterval().Milliseconds(),
+	}, nil
+}
+
+func (s *RegistryService) Heartbeat(_ context.Context, req *proto.HeartbeatRequest) (*proto.HeartbeatResponse, error) {
+	if !s.registry.Heartbeat(req.WorkerId) {
+		return nil, status.Errorf(codes.NotFound, "worker %q is not registered", req.WorkerId)
+	}
+	return &proto.HeartbeatResponse{}, nil
+}
+
+func (s *RegistryService) Deregister(_ context.Context, req *proto.DeregisterRequest) (*proto.DeregisterResponse, error) {
+	s.registry.Deregister(req.WorkerId)
+	return &proto.DeregisterResponse{}, nil
+}
+
+func NewRegistryService(registry *task.Registry) *RegistryService {
+	return &RegistryService{registry: registry}
+}
+
+// RegistryTokenInterceptor rejects the OkkRegistry calls without the bearer
+// token. The calls of the other services are left to their own authorization.
+func RegistryTokenInterceptor(token string) grpc.UnaryServerInterceptor {
+	prefix := "/" + proto.OkkRegistry_ServiceDesc.ServiceName + "/"
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !strings.HasPrefix(info.FullMethod, prefix) {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			presented, ok := strings.CutPrefix(value, "Bearer ")
+			if ok && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+				return handler(ctx, req)
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+	}
+}
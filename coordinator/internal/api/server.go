@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -12,12 +13,15 @@ import (
 
 type Server struct {
 	manager *task.Manager
+	auth    *Auth
 	mux     *http.ServeMux
 }
 
-func NewServer(manager *task.Manager) *Server {
+// NewServer returns a Server protected by auth, or serving everyone when auth is nil.
+func NewServer(manager *task.Manager, auth *Auth) *Server {
 	s := &Server{
 		manager: manager,
+		auth:    auth,
 		mux:     http.NewServeMux(),
 	}
 	s.registerRoutes()
@@ -29,12 +33,24 @@ func (s *Server) Handler() http.Handler {
 }
 
 func (s *Server) registerRoutes() {
-	s.mux.HandleFunc("POST /testcases", s.createTestCase)
-	s.mux.HandleFunc("GET /testcases", s.listTestCases)
-	s.mux.HandleFunc("GET /testcases/{name}", s.getTestCase)
-	s.mux.HandleFunc("DELETE /testcases/{name}", s.deleteTestCase)
-	s.mux.HandleFunc("GET /healthz", s.healthz)
-	s.mux.Handle("GET /metrics", promhttp.Handler())
+	publicHealthz := s.auth != nil && s.auth.PublicHealthz
+	publicMetrics := s.auth != nil && s.auth.PublicMetrics
+	s.mux.Handle("POST /testcases", s.authorize(RoleOperator, false, http.HandlerFunc(s.createTestCase)))
+	s.mux.Handle("GET /testcases", s.authorize(RoleReader, false, http.HandlerFunc(s.listTestCases)))
+	s.mux.Handle("GET /testcases/{name}", s.authorize(RoleReader, false, http.HandlerFunc(s.getTestCase)))
+	s.mux.Handle("GET /testcases/events", s.authorize(RoleReader, false, http.HandlerFunc(s.streamEvents)))
+	s.mux.Handle("GET /testcases/{name}/events", s.authorize(RoleReader, false, http.HandlerFunc(s.streamTestCaseEvents)))
+	s.mux.Handle("DELETE /testcases/{name}", s.authorize(RoleOperator, false, http.HandlerFunc(s.deleteTestCase)))
+	s.mux.Handle("POST /suites", s.authorize(RoleOperator, false, http.HandlerFunc(s.createSuite)))
+	s.mux.Handle("GET /suites", s.authorize(RoleReader, false, http.HandlerFunc(s.listSuites)))
+	s.mux.Handle("GET /suites/{name}", s.authorize(RoleReader, false, http.HandlerFunc(s.getSuite)))
+	s.mux.Handle("DELETE /suites/{name}", s.authorize(RoleOperator, false, http.HandlerFunc(s.deleteSuite)))
+	s.mux.Handle("POST /webhooks", s.authorize(RoleOperator, false, http.HandlerFunc(s.createWebhook)))
+	s.mux.Handle("GET /webhooks", s.authorize(RoleReader, false, http.HandlerFunc(s.listWebhooks)))
+	s.mux.Handle("DELETE /webhooks/{id}", s.authorize(RoleOperator, false, http.HandlerFunc(s.deleteWebhook)))
+	s.mux.Handle("GET /workers", s.authorize(RoleReader, false, http.HandlerFunc(s.listWorkers)))
+	s.mux.Handle("GET /healthz", s.authorize(RoleReader, publicHealthz, http.HandlerFunc(s.healthz)))
+	s.mux.Handle("GET /metrics", s.authorize(RoleReader, publicMetrics, promhttp.Handler()))
 }
 
 func (s *Server) createTestCase(w http.ResponseWriter, r *http.Request) {
@@ -44,25 +60,21 @@ func (s *Server) createTestCase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if tc.Name == "" {
-		writeError(w, http.StatusBadRequest, "name is required")
-		return
-	}
-	if tc.Type == "" {
-		writeError(w, http.StatusBadRequest, "type is required")
-		return
-	}
-	if tc.WorkerEndpoint == "" {
-		writeError(w, http.StatusBadRequest, "workerEndpoint is required")
+	if err := validateTestCase(&tc); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if err := s.manager.CreateTask(&tc); err != nil {
+		if errors.Is(err, task.ErrInvalidTestCase) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		writeError(w, http.StatusConflict, err.Error())
 		return
 	}
 
-	slog.Info("Testcase created", "name", tc.Name, "type", tc.Type)
+	slog.Info("Testcase created", "name", tc.Name, "type", tc.Type, "by", principalName(r))
 	writeJSON(w, http.StatusCreated, map[string]string{"status": "created", "name": tc.Name})
 }
 
@@ -84,18 +96,45 @@ func (s *Server) getTestCase(w http.ResponseWriter, r *http.Request) {
 func (s *Server) deleteTestCase(w http.ResponseWriter, r *http.Request) {
 	name := r.PathValue("name")
 	if err := s.manager.DeleteTask(name); err != nil {
+		if errors.Is(err, task.ErrSuiteMember) {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
 		writeError(w, http.StatusNotFound, err.Error())
 		return
 	}
 
-	slog.Info("Testcase deleted", "name", name)
+	slog.Info("Testcase deleted", "name", name, "by", principalName(r))
 	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted", "name": name})
 }
 
+func (s *Server) listWorkers(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"workers":    s.manager.ListWorkers(),
+		"registered": s.manager.Registry().List(),
+	})
+}
+
 func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "healthy"})
 }
 
+// validateTestCase checks the fields a testcase cannot be created without,
+// before it reaches the manager.
+func validateTestCase(tc *config.TestCaseConfig) error {
+	switch {
+	case tc.Name == "":
+		return errors.New("name is required")
+	case tc.Type == "":
+		return errors.New("type is required")
+	case tc.WorkerEndpoint == "" && len(tc.WorkerSelector) == 0:
+		return errors.New("workerEndpoint or workerSelector is required")
+	case tc.WorkerEndpoint != "" && len(tc.WorkerSelector) > 0:
+		return errors.New("workerEndpoint and workerSelector are mutually exclusive")
+	}
+	return nil
+}
+
 func writeJSON(w http.ResponseWriter, code int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
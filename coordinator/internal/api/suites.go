@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/task"
+)
+
+func (s *Server) createSuite(w http.ResponseWriter, r *http.Request) {
+	var sc config.SuiteConfig
+	if err := json.NewDecoder(r.Body).Decode(&sc); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if sc.Matrix != nil {
+		expanded, err := sc.Matrix.Expand(sc.Name)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid matrix: "+err.Error())
+			return
+		}
+		sc.TestCases = append(sc.TestCases, expanded...)
+	}
+	if err := validateSuite(&sc); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.manager.CreateSuite(&sc); err != nil {
+		if errors.Is(err, task.ErrInvalidSuite) || errors.Is(err, task.ErrInvalidTestCase) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	slog.Info("Suite created", "name", sc.Name, "testcases", len(sc.TestCases), "by", principalName(r))
+	status, _ := s.manager.GetSuiteStatus(sc.Name)
+	writeJSON(w, http.StatusCreated, status)
+}
+
+func (s *Server) listSuites(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"suites": s.manager.ListSuiteStatuses()})
+}
+
+func (s *Server) getSuite(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	status, found := s.manager.GetSuiteStatus(name)
+	if !found {
+		writeError(w, http.StatusNotFound, "suite not found: "+name)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) deleteSuite(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if err := s.manager.DeleteSuite(name); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	slog.Info("Suite deleted", "name", name, "by", principalName(r))
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted", "name": name})
+}
+
+// validateSuite checks the fields of a suite and of each of its testcases,
+// before it reaches the manager.
+func validateSuite(sc *config.SuiteConfig) error {
+	if sc.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(sc.TestCases) == 0 {
+		return errors.New("testcases or matrix is required")
+	}
+	for i, tc := range sc.TestCases {
+		if tc == nil {
+			return fmt.Errorf("testcases[%d] is null", i)
+		}
+		if err := validateTestCase(tc); err != nil {
+			return fmt.Errorf("testcases[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
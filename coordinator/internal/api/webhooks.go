@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/oxia-io/okk/coordinator/internal/task"
+)
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var webhook task.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	registered, err := s.manager.Webhooks().Register(&webhook)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	slog.Info("Webhook created", "id", registered.ID, "url", registered.URL, "by", principalName(r))
+	writeJSON(w, http.StatusCreated, registered)
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"webhooks": s.manager.Webhooks().List()})
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := s.manager.Webhooks().Delete(id); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	slog.Info("Webhook deleted", "id", id, "by", principalName(r))
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted", "id": id})
+}
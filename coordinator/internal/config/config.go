@@ -1,18 +1,112 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
 
 // TestCaseConfig replaces the K8s TestCase CRD with a standalone config struct.
 type TestCaseConfig struct {
-	Name           string            `json:"name"`
-	Type           string            `json:"type"`
-	Namespace      string            `json:"namespace,omitempty"`
-	WorkerEndpoint string            `json:"workerEndpoint"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Namespace      string `json:"namespace,omitempty"`
+	WorkerEndpoint string `json:"workerEndpoint,omitempty"`
+	// WorkerSelector picks the worker among the registered ones by label,
+	// instead of WorkerEndpoint.
+	WorkerSelector map[string]string `json:"workerSelector,omitempty"`
 	OpRate         int               `json:"opRate,omitempty"`
-	Duration       string            `json:"duration,omitempty"`
-	Properties     map[string]string `json:"properties,omitempty"`
+	// Duration bounds the run of the testcase from its creation. The final
+	// verification and teardown of the key-value testcases run after it.
+	Duration      string            `json:"duration,omitempty"`
+	AssertionMode string            `json:"assertionMode,omitempty"`
+	OpTimeout     string            `json:"opTimeout,omitempty"`
+	OpTimeouts    map[string]string `json:"opTimeouts,omitempty"`
+	FailurePolicy *FailurePolicy    `json:"failurePolicy,omitempty"`
+	WorkerAuth    *WorkerAuth       `json:"workerAuth,omitempty"`
+	Properties    map[string]string `json:"properties,omitempty"`
+	// Axes holds the axis values of a testcase expanded from a matrix.
+	Axes map[string]string `json:"axes,omitempty"`
+}
+
+// SuiteConfig is a named set of testcases created and deleted together. The
+// testcases of Matrix, named after the suite, are added to TestCases.
+type SuiteConfig struct {
+	Name      string            `json:"name"`
+	TestCases []*TestCaseConfig `json:"testcases,omitempty"`
+	Matrix    *MatrixConfig     `json:"matrix,omitempty"`
+}
+
+// WorkerAuth secures the connection to a worker endpoint. A testcase without
+// one uses the coordinator-wide WorkerAuth, and the connection is in
+// plaintext when neither is set.
+type WorkerAuth struct {
+	// TLS enables TLS, it is implied by any of the certificate fields.
+	TLS bool `json:"tls,omitempty"`
+	// CAFile verifies the worker certificate, the system roots are used when empty.
+	CAFile string `json:"caFile,omitempty"`
+	// CertFile and KeyFile are the client certificate presented for mutual TLS.
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+	// ServerName overrides the name the worker certificate is verified against.
+	ServerName string `json:"serverName,omitempty"`
+	// TokenFile holds a bearer token sent with every RPC. It is read on
+	// each RPC so that it can be rotated, and requires TLS.
+	TokenFile string `json:"tokenFile,omitempty"`
+}
+
+func (a *WorkerAuth) TLSEnabled() bool {
+	return a.TLS || a.CAFile != "" || a.CertFile != "" || a.KeyFile != "" || a.ServerName != ""
+}
+
+// FailurePolicy decides how a testcase reacts to failures. Without one an
+// operation is retried for up to 15 minutes and the testcase stops on the
+// first assertion failure.
+type FailurePolicy struct {
+	// MaxRetries bounds the retries of a single operation, 0 means unbounded.
+	MaxRetries uint64 `json:"maxRetries,omitempty"`
+	// MaxElapsed bounds the time spent retrying a single operation, or
+	// re-establishing the worker stream without making progress.
+	MaxElapsed string `json:"maxElapsed,omitempty"`
+	// ContinueOnAssertionFailure counts assertion failures and moves on to
+	// the next operation instead of stopping the testcase.
+	ContinueOnAssertionFailure bool `json:"continueOnAssertionFailure,omitempty"`
+	// ResyncOnAssertionFailure rebuilds the model of the generator from the
+	// store after an assertion failure, when the generator supports it.
+	// Only applies along with ContinueOnAssertionFailure.
+	ResyncOnAssertionFailure bool `json:"resyncOnAssertionFailure,omitempty"`
+	// StopOnNonRetryable stops the testcase on a non-retryable failure
+	// instead of skipping the operation.
+	StopOnNonRetryable bool `json:"stopOnNonRetryable,omitempty"`
+}
+
+func (c *TestCaseConfig) GetFailurePolicy() *FailurePolicy {
+	if c.FailurePolicy == nil {
+		return &FailurePolicy{}
+	}
+	return c.FailurePolicy
+}
+
+// Validate rejects a MaxElapsed that is not a positive duration, as a zero
+// one would retry forever. MaxRetries is unsigned, so a negative value is
+// already rejected when the config is decoded.
+func (p *FailurePolicy) Validate() error {
+	return validatePositiveDuration("failurePolicy.maxElapsed", p.MaxElapsed)
+}
+
+// GetMaxElapsed returns MaxElapsed, checked by Validate, or 15 minutes.
+func (p *FailurePolicy) GetMaxElapsed() time.Duration {
+	if p.MaxElapsed == "" {
+		return 15 * time.Minute
+	}
+	d, _ := time.ParseDuration(p.MaxElapsed)
+	return d
 }
 
+// DefaultOpTimeout is long enough for the worker to wait for a notification.
+const DefaultOpTimeout = 5 * time.Minute
+
 func (c *TestCaseConfig) GetOpRate() int {
 	if c.OpRate <= 0 {
 		return 10
@@ -32,6 +126,56 @@ func (c *TestCaseConfig) GetDuration() *time.Duration {
 	return &d
 }
 
+// OperationTypes are the operation names OpTimeouts is keyed by.
+var OperationTypes = []string{"put", "delete", "get", "list", "scan", "sessionRestart", "deleteRange", "sequenceUpdates"}
+
+// ValidateOpTimeouts rejects an OpTimeout or OpTimeouts value that is not a
+// positive duration, and an OpTimeouts key that is not in OperationTypes.
+func (c *TestCaseConfig) ValidateOpTimeouts() error {
+	if err := validatePositiveDuration("opTimeout", c.OpTimeout); err != nil {
+		return err
+	}
+	for opType, value := range c.OpTimeouts {
+		if !slices.Contains(OperationTypes, opType) {
+			return fmt.Errorf("unknown operation %q in opTimeouts, expected one of %s", opType, strings.Join(OperationTypes, ", "))
+		}
+		if err := validatePositiveDuration("opTimeouts."+opType, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePositiveDuration(field string, value string) error {
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("%s %q is not a positive duration", field, value)
+	}
+	return nil
+}
+
+// GetOpTimeout returns the deadline of an operation of opType, e.g. "put" or
+// "deleteRange", taken from OpTimeouts, then OpTimeout, then DefaultOpTimeout.
+// The values are checked by ValidateOpTimeouts.
+func (c *TestCaseConfig) GetOpTimeout(opType string) time.Duration {
+	for _, value := range []string{c.OpTimeouts[opType], c.OpTimeout} {
+		if value != "" {
+			d, _ := time.ParseDuration(value)
+			return d
+		}
+	}
+	return DefaultOpTimeout
+}
+
+// Assertion modes, see proto.AssertionMode. The worker mode is the default.
+const (
+	AssertionModeWorker      = "worker"
+	AssertionModeCoordinator = "coordinator"
+)
+
 // TestCase type constants.
 const (
 	TestCaseTypeBasicKv                  = "basic"
@@ -39,4 +183,7 @@ const (
 	TestCaseTypeMetadataWithEphemeral    = "metadataWithEphemeral"
 	TestCaseTypeMetadataWithNotification = "metadataWithNotification"
 	TestCaseTypeConditionalPut           = "conditionalPut"
+	TestCaseTypeSecondaryIndex           = "secondaryIndex"
+	TestCaseTypeHierarchicalKeys         = "hierarchicalKeys"
+	TestCaseTypeErrorCatalog             = "errorCatalog"
 )
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestValidateOpTimeouts(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		opTimeout  string
+		opTimeouts map[string]string
+		valid      bool
+		// expected are the timeouts of the operations when valid
+		expected map[string]time.Duration
+	}{
+		{name: "defaults", valid: true,
+			expected: map[string]time.Duration{"put": DefaultOpTimeout, "sequenceUpdates": DefaultOpTimeout}},
+		{name: "overrides", opTimeout: "30s", opTimeouts: map[string]string{"get": "1s", "deleteRange": "2m"}, valid: true,
+			expected: map[string]time.Duration{"put": 30 * time.Second, "get": time.Second, "deleteRange": 2 * time.Minute}},
+		{name: "operation override only", opTimeouts: map[string]string{"scan": "10s"}, valid: true,
+			expected: map[string]time.Duration{"scan": 10 * time.Second, "list": DefaultOpTimeout}},
+		{name: "malformed opTimeout", opTimeout: "30"},
+		{name: "zero opTimeout", opTimeout: "0s"},
+		{name: "negative opTimeout", opTimeout: "-1s"},
+		{name: "malformed operation timeout", opTimeouts: map[string]string{"get": "soon"}},
+		{name: "zero operation timeout", opTimeouts: map[string]string{"get": "0s"}},
+		{name: "unknown operation", opTimeouts: map[string]string{"gett": "1s"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			tc := &TestCaseConfig{OpTimeout: test.opTimeout, OpTimeouts: test.opTimeouts}
+			err := tc.ValidateOpTimeouts()
+			if (err == nil) != test.valid {
+				t.Fatalf("got error %v, expected valid: %v", err, test.valid)
+			}
+			for opType, expected := range test.expected {
+				if timeout := tc.GetOpTimeout(opType); timeout != expected {
+					t.Fatalf("%s timeout is %s, expected %s", opType, timeout, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFailurePolicy(t *testing.T) {
+	for _, test := range []struct {
+		policy   string
+		valid    bool
+		expected time.Duration
+	}{
+		{policy: `{}`, valid: true, expected: 15 * time.Minute},
+		{policy: `{"maxElapsed": "30s", "maxRetries": 3}`, valid: true, expected: 30 * time.Second},
+		{policy: `{"maxElapsed": "0s"}`},
+		{policy: `{"maxElapsed": "-1m"}`},
+		{policy: `{"maxElapsed": "forever"}`},
+		{policy: `{"maxRetries": -1}`},
+	} {
+		t.Run(test.policy, func(t *testing.T) {
+			var policy FailurePolicy
+			err := json.Unmarshal([]byte(test.policy), &policy)
+			if err == nil {
+				err = policy.Validate()
+			}
+			if (err == nil) != test.valid {
+				t.Fatalf("got error %v, expected valid: %v", err, test.valid)
+			}
+			if test.valid && policy.GetMaxElapsed() != test.expected {
+				t.Fatalf("maxElapsed is %s, expected %s", policy.GetMaxElapsed(), test.expected)
+			}
+		})
+	}
+}
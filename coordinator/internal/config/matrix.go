@@ -0,0 +1,146 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxMatrixSize bounds how many testcases a matrix expands into.
+const MaxMatrixSize = 256
+
+// AxisValue is a value of a matrix axis. It is given as a JSON string,
+// number or boolean, and kept as written.
+type AxisValue string
+
+func (v *AxisValue) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*v = AxisValue(s)
+		return nil
+	}
+	var scalar any
+	if err := json.Unmarshal(data, &scalar); err != nil {
+		return err
+	}
+	switch scalar.(type) {
+	case float64, bool:
+		*v = AxisValue(data)
+		return nil
+	default:
+		return fmt.Errorf("axis value %s is not a string, number or boolean", data)
+	}
+}
+
+// MatrixConfig expands a testcase template into one testcase per
+// combination of the values of its axes. An axis named after a testcase
+// field, e.g. "type" or "opRate", sets that field, and any other axis sets
+// the property of the same name.
+type MatrixConfig struct {
+	Template TestCaseConfig         `json:"template"`
+	Axes     map[string][]AxisValue `json:"axes"`
+}
+
+// matrixFields are the testcase fields an axis can set, the other axes set properties.
+var matrixFields = map[string]func(tc *TestCaseConfig, value string) error{
+	"type":           func(tc *TestCaseConfig, value string) error { tc.Type = value; return nil },
+	"namespace":      func(tc *TestCaseConfig, value string) error { tc.Namespace = value; return nil },
+	"workerEndpoint": func(tc *TestCaseConfig, value string) error { tc.WorkerEndpoint = value; return nil },
+	"duration":       func(tc *TestCaseConfig, value string) error { tc.Duration = value; return nil },
+	"assertionMode":  func(tc *TestCaseConfig, value string) error { tc.AssertionMode = value; return nil },
+	"opTimeout":      func(tc *TestCaseConfig, value string) error { tc.OpTimeout = value; return nil },
+	"opRate": func(tc *TestCaseConfig, value string) error {
+		opRate, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("opRate %q is not an integer", value)
+		}
+		tc.OpRate = opRate
+		return nil
+	},
+}
+
+var unsafeNameCharacters = regexp.MustCompile(`[^A-Za-z0-9.]+`)
+
+// Expand returns the testcases of the matrix, named after prefix and their
+// axis values in the order of the axis names, e.g. "sweep-100-10-basic" for
+// the keySpace, opRate and type axes. Each testcase records its axis values
+// in Axes. A name being the prefix of another is rejected, as the keys of the
+// testcases would overlap.
+func (m *MatrixConfig) Expand(prefix string) ([]*TestCaseConfig, error) {
+	if len(m.Axes) == 0 {
+		return nil, fmt.Errorf("matrix has no axis")
+	}
+	axes := slices.Sorted(maps.Keys(m.Axes))
+	size := 1
+	for _, axis := range axes {
+		if len(m.Axes[axis]) == 0 {
+			return nil, fmt.Errorf("axis %q has no value", axis)
+		}
+		size *= len(m.Axes[axis])
+		if size > MaxMatrixSize {
+			return nil, fmt.Errorf("matrix expands into more than %d testcases", MaxMatrixSize)
+		}
+	}
+
+	result := make([]*TestCaseConfig, 0, size)
+	names := make(map[string]struct{}, size)
+	// indexes is the combination being expanded, the last axis varies first
+	indexes := make([]int, len(axes))
+	for range size {
+		tc := m.Template
+		tc.WorkerSelector = maps.Clone(m.Template.WorkerSelector)
+		tc.OpTimeouts = maps.Clone(m.Template.OpTimeouts)
+		tc.Properties = maps.Clone(m.Template.Properties)
+		tc.Axes = make(map[string]string, len(axes))
+		nameParts := []string{prefix}
+		for i, axis := range axes {
+			value := string(m.Axes[axis][indexes[i]])
+			tc.Axes[axis] = value
+			nameParts = append(nameParts, strings.Trim(unsafeNameCharacters.ReplaceAllString(value, "-"), "-"))
+			if set, ok := matrixFields[axis]; ok {
+				if err := set(&tc, value); err != nil {
+					return nil, fmt.Errorf("axis %q: %w", axis, err)
+				}
+				continue
+			}
+			if tc.Properties == nil {
+				tc.Properties = make(map[string]string)
+			}
+			tc.Properties[axis] = value
+		}
+		tc.Name = strings.Join(nameParts, "-")
+		if _, duplicate := names[tc.Name]; duplicate {
+			return nil, fmt.Errorf("axis values expand into the testcase name %q twice", tc.Name)
+		}
+		names[tc.Name] = struct{}{}
+		result = append(result, &tc)
+
+		for i := len(indexes) - 1; i >= 0; i-- {
+			indexes[i]++
+			if indexes[i] < len(m.Axes[axes[i]]) {
+				break
+			}
+			indexes[i] = 0
+		}
+	}
+
+	// the keys of a testcase are prefixed with its name, so no name may be
+	// the prefix of another, and the names a name prefixes sort right after it
+	sorted := slices.Sorted(maps.Keys(names))
+	for i := 1; i < len(sorted); i++ {
+		if strings.HasPrefix(sorted[i], sorted[i-1]) {
+			return nil, fmt.Errorf("testcase name %q is a prefix of %q, so their keys would overlap: "+
+				"pad the axis values, e.g. with zeros", sorted[i-1], sorted[i])
+		}
+	}
+	return result, nil
+}
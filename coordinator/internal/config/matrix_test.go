@@ -0,0 +1,141 @@
+package config
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMatrixExpand(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		matrix   string
+		expected []*TestCaseConfig
+		// err is a substring of the expected error
+		err string
+	}{
+		{
+			name: "fields and properties",
+			matrix: `{"template": {"namespace": "default", "properties": {"verify": "true"}},
+				"axes": {"type": ["basic", "conditionalPut"], "opRate": [10, 20], "keySpace": ["1000"]}}`,
+			expected: []*TestCaseConfig{
+				{Name: "sweep-1000-10-basic", Type: "basic", OpRate: 10, Namespace: "default",
+					Properties: map[string]string{"verify": "true", "keySpace": "1000"},
+					Axes:       map[string]string{"type": "basic", "opRate": "10", "keySpace": "1000"}},
+				{Name: "sweep-1000-10-conditionalPut", Type: "conditionalPut", OpRate: 10, Namespace: "default",
+					Properties: map[string]string{"verify": "true", "keySpace": "1000"},
+					Axes:       map[string]string{"type": "conditionalPut", "opRate": "10", "keySpace": "1000"}},
+				{Name: "sweep-1000-20-basic", Type: "basic", OpRate: 20, Namespace: "default",
+					Properties: map[string]string{"verify": "true", "keySpace": "1000"},
+					Axes:       map[string]string{"type": "basic", "opRate": "20", "keySpace": "1000"}},
+				{Name: "sweep-1000-20-conditionalPut", Type: "conditionalPut", OpRate: 20, Namespace: "default",
+					Properties: map[string]string{"verify": "true", "keySpace": "1000"},
+					Axes:       map[string]string{"type": "conditionalPut", "opRate": "20", "keySpace": "1000"}},
+			},
+		},
+		{
+			name:   "unsafe characters in the name",
+			matrix: `{"axes": {"duration": ["1m30s"], "keyDistribution": ["zipf/1.2"], "verify": [true]}}`,
+			expected: []*TestCaseConfig{
+				{Name: "sweep-1m30s-zipf-1.2-true", Duration: "1m30s",
+					Properties: map[string]string{"keyDistribution": "zipf/1.2", "verify": "true"},
+					Axes:       map[string]string{"duration": "1m30s", "keyDistribution": "zipf/1.2", "verify": "true"}},
+			},
+		},
+		{
+			name:   "name prefix of another",
+			matrix: `{"axes": {"opRate": [10, 1000]}}`,
+			err:    `testcase name "sweep-10" is a prefix of "sweep-1000"`,
+		},
+		{
+			name:   "zero-padded values",
+			matrix: `{"axes": {"keySpace": ["0010", "1000"]}}`,
+			expected: []*TestCaseConfig{
+				{Name: "sweep-0010", Properties: map[string]string{"keySpace": "0010"}, Axes: map[string]string{"keySpace": "0010"}},
+				{Name: "sweep-1000", Properties: map[string]string{"keySpace": "1000"}, Axes: map[string]string{"keySpace": "1000"}},
+			},
+		},
+		{
+			name:   "duplicate names",
+			matrix: `{"axes": {"keyPrefix": ["a/b", "a-b"]}}`,
+			err:    `testcase name "sweep-a-b" twice`,
+		},
+		{
+			name:   "no axis",
+			matrix: `{"axes": {}}`,
+			err:    "matrix has no axis",
+		},
+		{
+			name:   "axis without value",
+			matrix: `{"axes": {"keySpace": [], "opRate": [10]}}`,
+			err:    `axis "keySpace" has no value`,
+		},
+		{
+			name:   "too many testcases",
+			matrix: `{"axes": {"a": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16], "b": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17]}}`,
+			err:    "more than 256 testcases",
+		},
+		{
+			name:   "non-integer opRate",
+			matrix: `{"axes": {"opRate": [1.5]}}`,
+			err:    `axis "opRate": opRate "1.5" is not an integer`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var matrix MatrixConfig
+			if err := json.Unmarshal([]byte(test.matrix), &matrix); err != nil {
+				t.Fatal(err)
+			}
+			testcases, err := matrix.Expand("sweep")
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("got error %v, expected %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(testcases) != len(test.expected) {
+				t.Fatalf("got %d testcases, expected %d", len(testcases), len(test.expected))
+			}
+			for i, tc := range testcases {
+				expected := test.expected[i]
+				if tc.Name != expected.Name || tc.Type != expected.Type || tc.OpRate != expected.OpRate ||
+					tc.Namespace != expected.Namespace || tc.Duration != expected.Duration ||
+					!maps.Equal(tc.Properties, expected.Properties) || !maps.Equal(tc.Axes, expected.Axes) {
+					t.Fatalf("testcase %d is %+v, expected %+v", i, tc, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestMatrixExpandClonesTemplate(t *testing.T) {
+	matrix := MatrixConfig{
+		Template: TestCaseConfig{
+			WorkerSelector: map[string]string{"zone": "a"},
+			OpTimeouts:     map[string]string{"put": "1s"},
+			Properties:     map[string]string{"verify": "true"},
+		},
+		Axes: map[string][]AxisValue{"keySpace": {"0010", "1000"}},
+	}
+	testcases, err := matrix.Expand("sweep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testcases[0].WorkerSelector["zone"] = "b"
+	testcases[0].OpTimeouts["put"] = "2s"
+	testcases[0].Properties["verify"] = "false"
+
+	for _, tc := range append(slices.Clone(testcases[1:]), &matrix.Template) {
+		if tc.WorkerSelector["zone"] != "a" || tc.OpTimeouts["put"] != "1s" || tc.Properties["verify"] != "true" {
+			t.Fatalf("testcase %q shares the maps of another testcase", tc.Name)
+		}
+	}
+	if _, set := matrix.Template.Properties["keySpace"]; set {
+		t.Fatal("the axis values were set on the template")
+	}
+}
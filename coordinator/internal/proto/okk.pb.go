@@ -76,6 +76,52 @@ func (KeyComparisonType) EnumDescriptor() ([]byte, []int) {
 	return file_okk_proto_rawDescGZIP(), []int{0}
 }
 
+type SubscriptionAction int32
+
+const (
+	SubscriptionAction_SUBSCRIBE   SubscriptionAction = 0
+	SubscriptionAction_UNSUBSCRIBE SubscriptionAction = 1
+)
+
+// Enum value maps for SubscriptionAction.
+var (
+	SubscriptionAction_name = map[int32]string{
+		0: "SUBSCRIBE",
+		1: "UNSUBSCRIBE",
+	}
+	SubscriptionAction_value = map[string]int32{
+		"SUBSCRIBE":   0,
+		"UNSUBSCRIBE": 1,
+	}
+)
+
+func (x SubscriptionAction) Enum() *SubscriptionAction {
+	p := new(SubscriptionAction)
+	*p = x
+	return p
+}
+
+func (x SubscriptionAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_okk_proto_enumTypes[1].Descriptor()
+}
+
+func (SubscriptionAction) Type() protoreflect.EnumType {
+	return &file_okk_proto_enumTypes[1]
+}
+
+func (x SubscriptionAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionAction.Descriptor instead.
+func (SubscriptionAction) EnumDescriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{1}
+}
+
 type NotificationType int32
 
 const (
@@ -112,11 +158,11 @@ func (x NotificationType) String() string {
 }
 
 func (NotificationType) Descriptor() protoreflect.EnumDescriptor {
-	return file_okk_proto_enumTypes[1].Descriptor()
+	return file_okk_proto_enumTypes[2].Descriptor()
 }
 
 func (NotificationType) Type() protoreflect.EnumType {
-	return &file_okk_proto_enumTypes[1]
+	return &file_okk_proto_enumTypes[2]
 }
 
 func (x NotificationType) Number() protoreflect.EnumNumber {
@@ -125,7 +171,120 @@ func (x NotificationType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use NotificationType.Descriptor instead.
 func (NotificationType) EnumDescriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{1}
+	return file_okk_proto_rawDescGZIP(), []int{2}
+}
+
+// ExpectedError is the catalog of errors an assertion can expect.
+type ExpectedError int32
+
+const (
+	ExpectedError_NO_ERROR ExpectedError = 0
+	// A get found no record, or a delete had nothing to delete.
+	ExpectedError_KEY_NOT_FOUND ExpectedError = 1
+	// A put with an expected version id, or one that must not exist, was rejected.
+	ExpectedError_VERSION_CONFLICT ExpectedError = 2
+	// The session of an ephemeral record no longer exists.
+	ExpectedError_SESSION_EXPIRED ExpectedError = 3
+	// A list, scan or delete range was rejected for its bounds.
+	ExpectedError_INVALID_RANGE ExpectedError = 4
+	// A request exceeded the maximum size accepted by the server.
+	ExpectedError_REQUEST_TOO_LARGE ExpectedError = 5
+)
+
+// Enum value maps for ExpectedError.
+var (
+	ExpectedError_name = map[int32]string{
+		0: "NO_ERROR",
+		1: "KEY_NOT_FOUND",
+		2: "VERSION_CONFLICT",
+		3: "SESSION_EXPIRED",
+		4: "INVALID_RANGE",
+		5: "REQUEST_TOO_LARGE",
+	}
+	ExpectedError_value = map[string]int32{
+		"NO_ERROR":          0,
+		"KEY_NOT_FOUND":     1,
+		"VERSION_CONFLICT":  2,
+		"SESSION_EXPIRED":   3,
+		"INVALID_RANGE":     4,
+		"REQUEST_TOO_LARGE": 5,
+	}
+)
+
+func (x ExpectedError) Enum() *ExpectedError {
+	p := new(ExpectedError)
+	*p = x
+	return p
+}
+
+func (x ExpectedError) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExpectedError) Descriptor() protoreflect.EnumDescriptor {
+	return file_okk_proto_enumTypes[3].Descriptor()
+}
+
+func (ExpectedError) Type() protoreflect.EnumType {
+	return &file_okk_proto_enumTypes[3]
+}
+
+func (x ExpectedError) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExpectedError.Descriptor instead.
+func (ExpectedError) EnumDescriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{3}
+}
+
+type AssertionMode int32
+
+const (
+	// The worker evaluates the assertion and reports the outcome in the status.
+	AssertionMode_WORKER AssertionMode = 0
+	// The worker reports what it observed in the response and the coordinator
+	// evaluates the assertion against it.
+	AssertionMode_COORDINATOR AssertionMode = 1
+)
+
+// Enum value maps for AssertionMode.
+var (
+	AssertionMode_name = map[int32]string{
+		0: "WORKER",
+		1: "COORDINATOR",
+	}
+	AssertionMode_value = map[string]int32{
+		"WORKER":      0,
+		"COORDINATOR": 1,
+	}
+)
+
+func (x AssertionMode) Enum() *AssertionMode {
+	p := new(AssertionMode)
+	*p = x
+	return p
+}
+
+func (x AssertionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssertionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_okk_proto_enumTypes[4].Descriptor()
+}
+
+func (AssertionMode) Type() protoreflect.EnumType {
+	return &file_okk_proto_enumTypes[4]
+}
+
+func (x AssertionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssertionMode.Descriptor instead.
+func (AssertionMode) EnumDescriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{4}
 }
 
 type Status int32
@@ -164,11 +323,11 @@ func (x Status) String() string {
 }
 
 func (Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_okk_proto_enumTypes[2].Descriptor()
+	return file_okk_proto_enumTypes[5].Descriptor()
 }
 
 func (Status) Type() protoreflect.EnumType {
-	return &file_okk_proto_enumTypes[2]
+	return &file_okk_proto_enumTypes[5]
 }
 
 func (x Status) Number() protoreflect.EnumNumber {
@@ -177,7 +336,7 @@ func (x Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Status.Descriptor instead.
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{2}
+	return file_okk_proto_rawDescGZIP(), []int{5}
 }
 
 type OperationSessionRestart struct {
@@ -216,6 +375,58 @@ func (*OperationSessionRestart) Descriptor() ([]byte, []int) {
 	return file_okk_proto_rawDescGZIP(), []int{0}
 }
 
+type SecondaryIndex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IndexName     string                 `protobuf:"bytes,1,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	SecondaryKey  string                 `protobuf:"bytes,2,opt,name=secondary_key,json=secondaryKey,proto3" json:"secondary_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecondaryIndex) Reset() {
+	*x = SecondaryIndex{}
+	mi := &file_okk_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecondaryIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecondaryIndex) ProtoMessage() {}
+
+func (x *SecondaryIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecondaryIndex.ProtoReflect.Descriptor instead.
+func (*SecondaryIndex) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SecondaryIndex) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *SecondaryIndex) GetSecondaryKey() string {
+	if x != nil {
+		return x.SecondaryKey
+	}
+	return ""
+}
+
 type OperationPut struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Key               string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -224,13 +435,14 @@ type OperationPut struct {
 	PartitionKey      *string                `protobuf:"bytes,4,opt,name=partition_key,json=partitionKey,proto3,oneof" json:"partition_key,omitempty"`
 	SequenceKeyDelta  []uint64               `protobuf:"varint,5,rep,packed,name=sequence_key_delta,json=sequenceKeyDelta,proto3" json:"sequence_key_delta,omitempty"`
 	ExpectedVersionId *int64                 `protobuf:"varint,6,opt,name=expected_version_id,json=expectedVersionId,proto3,oneof" json:"expected_version_id,omitempty"`
+	SecondaryIndexes  []*SecondaryIndex      `protobuf:"bytes,7,rep,name=secondary_indexes,json=secondaryIndexes,proto3" json:"secondary_indexes,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *OperationPut) Reset() {
 	*x = OperationPut{}
-	mi := &file_okk_proto_msgTypes[1]
+	mi := &file_okk_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -242,7 +454,7 @@ func (x *OperationPut) String() string {
 func (*OperationPut) ProtoMessage() {}
 
 func (x *OperationPut) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[1]
+	mi := &file_okk_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +467,7 @@ func (x *OperationPut) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationPut.ProtoReflect.Descriptor instead.
 func (*OperationPut) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{1}
+	return file_okk_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *OperationPut) GetKey() string {
@@ -300,17 +512,25 @@ func (x *OperationPut) GetExpectedVersionId() int64 {
 	return 0
 }
 
+func (x *OperationPut) GetSecondaryIndexes() []*SecondaryIndex {
+	if x != nil {
+		return x.SecondaryIndexes
+	}
+	return nil
+}
+
 type OperationGet struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Key            string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	ComparisonType KeyComparisonType      `protobuf:"varint,2,opt,name=comparison_type,json=comparisonType,proto3,enum=io.oxia.okk.proto.v1.KeyComparisonType" json:"comparison_type,omitempty"`
+	UseIndex       *string                `protobuf:"bytes,3,opt,name=use_index,json=useIndex,proto3,oneof" json:"use_index,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *OperationGet) Reset() {
 	*x = OperationGet{}
-	mi := &file_okk_proto_msgTypes[2]
+	mi := &file_okk_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -322,7 +542,7 @@ func (x *OperationGet) String() string {
 func (*OperationGet) ProtoMessage() {}
 
 func (x *OperationGet) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[2]
+	mi := &file_okk_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +555,7 @@ func (x *OperationGet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationGet.ProtoReflect.Descriptor instead.
 func (*OperationGet) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{2}
+	return file_okk_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *OperationGet) GetKey() string {
@@ -352,17 +572,25 @@ func (x *OperationGet) GetComparisonType() KeyComparisonType {
 	return KeyComparisonType_EQUAL
 }
 
+func (x *OperationGet) GetUseIndex() string {
+	if x != nil && x.UseIndex != nil {
+		return *x.UseIndex
+	}
+	return ""
+}
+
 type OperationList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	KeyStart      string                 `protobuf:"bytes,1,opt,name=key_start,json=keyStart,proto3" json:"key_start,omitempty"`
 	KeyEnd        string                 `protobuf:"bytes,2,opt,name=key_end,json=keyEnd,proto3" json:"key_end,omitempty"`
+	UseIndex      *string                `protobuf:"bytes,3,opt,name=use_index,json=useIndex,proto3,oneof" json:"use_index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OperationList) Reset() {
 	*x = OperationList{}
-	mi := &file_okk_proto_msgTypes[3]
+	mi := &file_okk_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -374,7 +602,7 @@ func (x *OperationList) String() string {
 func (*OperationList) ProtoMessage() {}
 
 func (x *OperationList) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[3]
+	mi := &file_okk_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,7 +615,7 @@ func (x *OperationList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationList.ProtoReflect.Descriptor instead.
 func (*OperationList) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{3}
+	return file_okk_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *OperationList) GetKeyStart() string {
@@ -404,6 +632,13 @@ func (x *OperationList) GetKeyEnd() string {
 	return ""
 }
 
+func (x *OperationList) GetUseIndex() string {
+	if x != nil && x.UseIndex != nil {
+		return *x.UseIndex
+	}
+	return ""
+}
+
 type OperationScan struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	KeyStart      string                 `protobuf:"bytes,1,opt,name=key_start,json=keyStart,proto3" json:"key_start,omitempty"`
@@ -414,7 +649,7 @@ type OperationScan struct {
 
 func (x *OperationScan) Reset() {
 	*x = OperationScan{}
-	mi := &file_okk_proto_msgTypes[4]
+	mi := &file_okk_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -426,7 +661,7 @@ func (x *OperationScan) String() string {
 func (*OperationScan) ProtoMessage() {}
 
 func (x *OperationScan) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[4]
+	mi := &file_okk_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -439,7 +674,7 @@ func (x *OperationScan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationScan.ProtoReflect.Descriptor instead.
 func (*OperationScan) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{4}
+	return file_okk_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *OperationScan) GetKeyStart() string {
@@ -465,7 +700,7 @@ type OperationDelete struct {
 
 func (x *OperationDelete) Reset() {
 	*x = OperationDelete{}
-	mi := &file_okk_proto_msgTypes[5]
+	mi := &file_okk_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -477,7 +712,7 @@ func (x *OperationDelete) String() string {
 func (*OperationDelete) ProtoMessage() {}
 
 func (x *OperationDelete) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[5]
+	mi := &file_okk_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -490,7 +725,7 @@ func (x *OperationDelete) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationDelete.ProtoReflect.Descriptor instead.
 func (*OperationDelete) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{5}
+	return file_okk_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OperationDelete) GetKey() string {
@@ -510,7 +745,7 @@ type OperationDeleteRange struct {
 
 func (x *OperationDeleteRange) Reset() {
 	*x = OperationDeleteRange{}
-	mi := &file_okk_proto_msgTypes[6]
+	mi := &file_okk_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -522,7 +757,7 @@ func (x *OperationDeleteRange) String() string {
 func (*OperationDeleteRange) ProtoMessage() {}
 
 func (x *OperationDeleteRange) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[6]
+	mi := &file_okk_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,7 +770,7 @@ func (x *OperationDeleteRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationDeleteRange.ProtoReflect.Descriptor instead.
 func (*OperationDeleteRange) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{6}
+	return file_okk_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *OperationDeleteRange) GetKeyStart() string {
@@ -552,11 +787,73 @@ func (x *OperationDeleteRange) GetKeyEnd() string {
 	return ""
 }
 
+type OperationSequenceUpdates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	PartitionKey  string                 `protobuf:"bytes,2,opt,name=partition_key,json=partitionKey,proto3" json:"partition_key,omitempty"`
+	Action        SubscriptionAction     `protobuf:"varint,3,opt,name=action,proto3,enum=io.oxia.okk.proto.v1.SubscriptionAction" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationSequenceUpdates) Reset() {
+	*x = OperationSequenceUpdates{}
+	mi := &file_okk_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationSequenceUpdates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationSequenceUpdates) ProtoMessage() {}
+
+func (x *OperationSequenceUpdates) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationSequenceUpdates.ProtoReflect.Descriptor instead.
+func (*OperationSequenceUpdates) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OperationSequenceUpdates) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *OperationSequenceUpdates) GetPartitionKey() string {
+	if x != nil {
+		return x.PartitionKey
+	}
+	return ""
+}
+
+func (x *OperationSequenceUpdates) GetAction() SubscriptionAction {
+	if x != nil {
+		return x.Action
+	}
+	return SubscriptionAction_SUBSCRIBE
+}
+
 type Operation struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	Sequence     int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
-	Assertion    *Assertion             `protobuf:"bytes,2,opt,name=assertion,proto3,oneof" json:"assertion,omitempty"`
-	Precondition *Precondition          `protobuf:"bytes,3,opt,name=precondition,proto3,oneof" json:"precondition,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stamped by the coordinator, monotonic within a testcase. Retries of an
+	// operation keep its sequence.
+	Sequence     int64         `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Assertion    *Assertion    `protobuf:"bytes,2,opt,name=assertion,proto3,oneof" json:"assertion,omitempty"`
+	Precondition *Precondition `protobuf:"bytes,3,opt,name=precondition,proto3,oneof" json:"precondition,omitempty"`
 	// Types that are valid to be assigned to Operation:
 	//
 	//	*Operation_Put
@@ -566,6 +863,7 @@ type Operation struct {
 	//	*Operation_Scan
 	//	*Operation_SessionRestart
 	//	*Operation_DeleteRange
+	//	*Operation_SequenceUpdates
 	Operation     isOperation_Operation `protobuf_oneof:"operation"`
 	Timestamp     int64                 `protobuf:"varint,100,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -574,7 +872,7 @@ type Operation struct {
 
 func (x *Operation) Reset() {
 	*x = Operation{}
-	mi := &file_okk_proto_msgTypes[7]
+	mi := &file_okk_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -586,7 +884,7 @@ func (x *Operation) String() string {
 func (*Operation) ProtoMessage() {}
 
 func (x *Operation) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[7]
+	mi := &file_okk_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -599,7 +897,7 @@ func (x *Operation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Operation.ProtoReflect.Descriptor instead.
 func (*Operation) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{7}
+	return file_okk_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Operation) GetSequence() int64 {
@@ -693,6 +991,15 @@ func (x *Operation) GetDeleteRange() *OperationDeleteRange {
 	return nil
 }
 
+func (x *Operation) GetSequenceUpdates() *OperationSequenceUpdates {
+	if x != nil {
+		if x, ok := x.Operation.(*Operation_SequenceUpdates); ok {
+			return x.SequenceUpdates
+		}
+	}
+	return nil
+}
+
 func (x *Operation) GetTimestamp() int64 {
 	if x != nil {
 		return x.Timestamp
@@ -732,6 +1039,10 @@ type Operation_DeleteRange struct {
 	DeleteRange *OperationDeleteRange `protobuf:"bytes,10,opt,name=delete_range,json=deleteRange,proto3,oneof"`
 }
 
+type Operation_SequenceUpdates struct {
+	SequenceUpdates *OperationSequenceUpdates `protobuf:"bytes,11,opt,name=sequence_updates,json=sequenceUpdates,proto3,oneof"`
+}
+
 func (*Operation_Put) isOperation_Operation() {}
 
 func (*Operation_Delete) isOperation_Operation() {}
@@ -746,6 +1057,8 @@ func (*Operation_SessionRestart) isOperation_Operation() {}
 
 func (*Operation_DeleteRange) isOperation_Operation() {}
 
+func (*Operation_SequenceUpdates) isOperation_Operation() {}
+
 type Precondition struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
 	WatchNotification      *bool                  `protobuf:"varint,1,opt,name=watch_notification,json=watchNotification,proto3,oneof" json:"watch_notification,omitempty"`
@@ -756,7 +1069,7 @@ type Precondition struct {
 
 func (x *Precondition) Reset() {
 	*x = Precondition{}
-	mi := &file_okk_proto_msgTypes[8]
+	mi := &file_okk_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -768,7 +1081,7 @@ func (x *Precondition) String() string {
 func (*Precondition) ProtoMessage() {}
 
 func (x *Precondition) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[8]
+	mi := &file_okk_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -781,7 +1094,7 @@ func (x *Precondition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Precondition.ProtoReflect.Descriptor instead.
 func (*Precondition) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{8}
+	return file_okk_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Precondition) GetWatchNotification() bool {
@@ -810,7 +1123,7 @@ type Notification struct {
 
 func (x *Notification) Reset() {
 	*x = Notification{}
-	mi := &file_okk_proto_msgTypes[9]
+	mi := &file_okk_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -822,7 +1135,7 @@ func (x *Notification) String() string {
 func (*Notification) ProtoMessage() {}
 
 func (x *Notification) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[9]
+	mi := &file_okk_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,7 +1148,7 @@ func (x *Notification) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Notification.ProtoReflect.Descriptor instead.
 func (*Notification) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{9}
+	return file_okk_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Notification) GetType() NotificationType {
@@ -867,16 +1180,22 @@ func (x *Notification) GetKeyEnd() string {
 }
 
 type Record struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// When set, the value is asserted by its CRC32C checksum and length
+	// instead of byte equality, so large values do not travel in assertions.
+	ValueChecksum *uint32 `protobuf:"fixed32,3,opt,name=value_checksum,json=valueChecksum,proto3,oneof" json:"value_checksum,omitempty"`
+	ValueLength   *uint64 `protobuf:"varint,4,opt,name=value_length,json=valueLength,proto3,oneof" json:"value_length,omitempty"`
+	// Reported by the worker for the records it read.
+	VersionId     *int64 `protobuf:"varint,5,opt,name=version_id,json=versionId,proto3,oneof" json:"version_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Record) Reset() {
 	*x = Record{}
-	mi := &file_okk_proto_msgTypes[10]
+	mi := &file_okk_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -888,7 +1207,7 @@ func (x *Record) String() string {
 func (*Record) ProtoMessage() {}
 
 func (x *Record) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[10]
+	mi := &file_okk_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -901,7 +1220,7 @@ func (x *Record) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Record.ProtoReflect.Descriptor instead.
 func (*Record) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{10}
+	return file_okk_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Record) GetKey() string {
@@ -918,33 +1237,1672 @@ func (x *Record) GetValue() []byte {
 	return nil
 }
 
-type Assertion struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	EventuallyEmpty       *bool                  `protobuf:"varint,1,opt,name=eventually_empty,json=eventuallyEmpty,proto3,oneof" json:"eventually_empty,omitempty"`
-	EmptyRecords          *bool                  `protobuf:"varint,2,opt,name=empty_records,json=emptyRecords,proto3,oneof" json:"empty_records,omitempty"`
-	PartitionKey          *string                `protobuf:"bytes,3,opt,name=partition_key,json=partitionKey,proto3,oneof" json:"partition_key,omitempty"`
-	Records               []*Record              `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`
-	Notification          *Notification          `protobuf:"bytes,5,opt,name=notification,proto3,oneof" json:"notification,omitempty"`
-	ExpectVersionConflict *bool                  `protobuf:"varint,6,opt,name=expect_version_conflict,json=expectVersionConflict,proto3,oneof" json:"expect_version_conflict,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+func (x *Record) GetValueChecksum() uint32 {
+	if x != nil && x.ValueChecksum != nil {
+		return *x.ValueChecksum
+	}
+	return 0
+}
+
+func (x *Record) GetValueLength() uint64 {
+	if x != nil && x.ValueLength != nil {
+		return *x.ValueLength
+	}
+	return 0
+}
+
+func (x *Record) GetVersionId() int64 {
+	if x != nil && x.VersionId != nil {
+		return *x.VersionId
+	}
+	return 0
+}
+
+// Eventually lets an assertion fail for a while before it is a failure.
+// Reads are executed again every poll interval until the assertion holds or
+// the timeout, counted from the operation timestamp, elapses. Writes are
+// never executed again, the worker waits up to the timeout for the
+// notification they assert instead.
+type Eventually struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TimeoutMillis      int64                  `protobuf:"varint,1,opt,name=timeout_millis,json=timeoutMillis,proto3" json:"timeout_millis,omitempty"`
+	PollIntervalMillis int64                  `protobuf:"varint,2,opt,name=poll_interval_millis,json=pollIntervalMillis,proto3" json:"poll_interval_millis,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Eventually) Reset() {
+	*x = Eventually{}
+	mi := &file_okk_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Eventually) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Eventually) ProtoMessage() {}
+
+func (x *Eventually) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Eventually.ProtoReflect.Descriptor instead.
+func (*Eventually) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Eventually) GetTimeoutMillis() int64 {
+	if x != nil {
+		return x.TimeoutMillis
+	}
+	return 0
+}
+
+func (x *Eventually) GetPollIntervalMillis() int64 {
+	if x != nil {
+		return x.PollIntervalMillis
+	}
+	return 0
+}
+
+type Assertion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: use empty_records along with eventually.
+	EventuallyEmpty *bool         `protobuf:"varint,1,opt,name=eventually_empty,json=eventuallyEmpty,proto3,oneof" json:"eventually_empty,omitempty"`
+	EmptyRecords    *bool         `protobuf:"varint,2,opt,name=empty_records,json=emptyRecords,proto3,oneof" json:"empty_records,omitempty"`
+	PartitionKey    *string       `protobuf:"bytes,3,opt,name=partition_key,json=partitionKey,proto3,oneof" json:"partition_key,omitempty"`
+	Records         []*Record     `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`
+	Notification    *Notification `protobuf:"bytes,5,opt,name=notification,proto3,oneof" json:"notification,omitempty"`
+	// Deprecated: use expected_error VERSION_CONFLICT.
+	ExpectVersionConflict *bool `protobuf:"varint,6,opt,name=expect_version_conflict,json=expectVersionConflict,proto3,oneof" json:"expect_version_conflict,omitempty"`
+	// Sequence keys produced while a sequence updates subscription was active,
+	// in order. Checked when the subscription is stopped.
+	SequenceUpdates []string    `protobuf:"bytes,7,rep,name=sequence_updates,json=sequenceUpdates,proto3" json:"sequence_updates,omitempty"`
+	Eventually      *Eventually `protobuf:"bytes,8,opt,name=eventually,proto3,oneof" json:"eventually,omitempty"`
+	// The error the operation must fail with. The records and notification
+	// are not checked when it is set.
+	ExpectedError *ExpectedError `protobuf:"varint,9,opt,name=expected_error,json=expectedError,proto3,enum=io.oxia.okk.proto.v1.ExpectedError,oneof" json:"expected_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assertion) Reset() {
+	*x = Assertion{}
+	mi := &file_okk_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assertion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assertion) ProtoMessage() {}
+
+func (x *Assertion) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assertion.ProtoReflect.Descriptor instead.
+func (*Assertion) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Assertion) GetEventuallyEmpty() bool {
+	if x != nil && x.EventuallyEmpty != nil {
+		return *x.EventuallyEmpty
+	}
+	return false
+}
+
+func (x *Assertion) GetEmptyRecords() bool {
+	if x != nil && x.EmptyRecords != nil {
+		return *x.EmptyRecords
+	}
+	return false
+}
+
+func (x *Assertion) GetPartitionKey() string {
+	if x != nil && x.PartitionKey != nil {
+		return *x.PartitionKey
+	}
+	return ""
+}
+
+func (x *Assertion) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *Assertion) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *Assertion) GetExpectVersionConflict() bool {
+	if x != nil && x.ExpectVersionConflict != nil {
+		return *x.ExpectVersionConflict
+	}
+	return false
+}
+
+func (x *Assertion) GetSequenceUpdates() []string {
+	if x != nil {
+		return x.SequenceUpdates
+	}
+	return nil
+}
+
+func (x *Assertion) GetEventually() *Eventually {
+	if x != nil {
+		return x.Eventually
+	}
+	return nil
+}
+
+func (x *Assertion) GetExpectedError() ExpectedError {
+	if x != nil && x.ExpectedError != nil {
+		return *x.ExpectedError
+	}
+	return ExpectedError_NO_ERROR
+}
+
+type ExecuteCommand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Testcase      string                 `protobuf:"bytes,1,opt,name=testcase,proto3" json:"testcase,omitempty"`
+	Operation     *Operation             `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	AssertionMode AssertionMode          `protobuf:"varint,4,opt,name=assertion_mode,json=assertionMode,proto3,enum=io.oxia.okk.proto.v1.AssertionMode" json:"assertion_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteCommand) Reset() {
+	*x = ExecuteCommand{}
+	mi := &file_okk_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteCommand) ProtoMessage() {}
+
+func (x *ExecuteCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteCommand.ProtoReflect.Descriptor instead.
+func (*ExecuteCommand) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExecuteCommand) GetTestcase() string {
+	if x != nil {
+		return x.Testcase
+	}
+	return ""
+}
+
+func (x *ExecuteCommand) GetOperation() *Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *ExecuteCommand) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ExecuteCommand) GetAssertionMode() AssertionMode {
+	if x != nil {
+		return x.AssertionMode
+	}
+	return AssertionMode_WORKER
+}
+
+// AssertionFailureDetail describes what an assertion expected and what was
+// actually observed.
+type AssertionFailureDetail struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sequence int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The key, or the first key of the range, the operation targeted.
+	Key                  string        `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Message              string        `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	ExpectedRecords      []*Record     `protobuf:"bytes,4,rep,name=expected_records,json=expectedRecords,proto3" json:"expected_records,omitempty"`
+	ActualRecords        []*Record     `protobuf:"bytes,5,rep,name=actual_records,json=actualRecords,proto3" json:"actual_records,omitempty"`
+	ExpectedNotification *Notification `protobuf:"bytes,6,opt,name=expected_notification,json=expectedNotification,proto3,oneof" json:"expected_notification,omitempty"`
+	ActualNotification   *Notification `protobuf:"bytes,7,opt,name=actual_notification,json=actualNotification,proto3,oneof" json:"actual_notification,omitempty"`
+	// Unix nanoseconds at which the operation was generated and at which the
+	// mismatch was observed.
+	OperationTimestamp int64          `protobuf:"varint,8,opt,name=operation_timestamp,json=operationTimestamp,proto3" json:"operation_timestamp,omitempty"`
+	ObservedTimestamp  int64          `protobuf:"varint,9,opt,name=observed_timestamp,json=observedTimestamp,proto3" json:"observed_timestamp,omitempty"`
+	WorkerId           string         `protobuf:"bytes,10,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	ExpectedError      *ExpectedError `protobuf:"varint,11,opt,name=expected_error,json=expectedError,proto3,enum=io.oxia.okk.proto.v1.ExpectedError,oneof" json:"expected_error,omitempty"`
+	ActualError        *ExpectedError `protobuf:"varint,12,opt,name=actual_error,json=actualError,proto3,enum=io.oxia.okk.proto.v1.ExpectedError,oneof" json:"actual_error,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AssertionFailureDetail) Reset() {
+	*x = AssertionFailureDetail{}
+	mi := &file_okk_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssertionFailureDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssertionFailureDetail) ProtoMessage() {}
+
+func (x *AssertionFailureDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssertionFailureDetail.ProtoReflect.Descriptor instead.
+func (*AssertionFailureDetail) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AssertionFailureDetail) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AssertionFailureDetail) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AssertionFailureDetail) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AssertionFailureDetail) GetExpectedRecords() []*Record {
+	if x != nil {
+		return x.ExpectedRecords
+	}
+	return nil
+}
+
+func (x *AssertionFailureDetail) GetActualRecords() []*Record {
+	if x != nil {
+		return x.ActualRecords
+	}
+	return nil
+}
+
+func (x *AssertionFailureDetail) GetExpectedNotification() *Notification {
+	if x != nil {
+		return x.ExpectedNotification
+	}
+	return nil
+}
+
+func (x *AssertionFailureDetail) GetActualNotification() *Notification {
+	if x != nil {
+		return x.ActualNotification
+	}
+	return nil
+}
+
+func (x *AssertionFailureDetail) GetOperationTimestamp() int64 {
+	if x != nil {
+		return x.OperationTimestamp
+	}
+	return 0
+}
+
+func (x *AssertionFailureDetail) GetObservedTimestamp() int64 {
+	if x != nil {
+		return x.ObservedTimestamp
+	}
+	return 0
+}
+
+func (x *AssertionFailureDetail) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *AssertionFailureDetail) GetExpectedError() ExpectedError {
+	if x != nil && x.ExpectedError != nil {
+		return *x.ExpectedError
+	}
+	return ExpectedError_NO_ERROR
+}
+
+func (x *AssertionFailureDetail) GetActualError() ExpectedError {
+	if x != nil && x.ActualError != nil {
+		return *x.ActualError
+	}
+	return ExpectedError_NO_ERROR
+}
+
+type ExecuteResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Status     Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=io.oxia.okk.proto.v1.Status" json:"status,omitempty"`
+	StatusInfo string                 `protobuf:"bytes,2,opt,name=status_info,json=statusInfo,proto3" json:"status_info,omitempty"`
+	VersionId  *int64                 `protobuf:"varint,3,opt,name=version_id,json=versionId,proto3,oneof" json:"version_id,omitempty"`
+	// Observations, only reported in the COORDINATOR assertion mode.
+	// The records read by a get, list or scan, or the key written by a put.
+	Records []*Record `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`
+	// The notification received, when the assertion expects one.
+	Notification *Notification `protobuf:"bytes,5,opt,name=notification,proto3,oneof" json:"notification,omitempty"`
+	// Whether a put with an expected version id was rejected.
+	VersionConflict *bool `protobuf:"varint,6,opt,name=version_conflict,json=versionConflict,proto3,oneof" json:"version_conflict,omitempty"`
+	// The sequence keys received by a sequence updates subscription.
+	SequenceUpdates []string `protobuf:"bytes,7,rep,name=sequence_updates,json=sequenceUpdates,proto3" json:"sequence_updates,omitempty"`
+	// Set by the worker along with the AssertionFailure status.
+	FailureDetail *AssertionFailureDetail `protobuf:"bytes,8,opt,name=failure_detail,json=failureDetail,proto3,oneof" json:"failure_detail,omitempty"`
+	// The sequence of the operation this responds to.
+	Sequence int64 `protobuf:"varint,9,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The error the operation failed with, when the assertion expects one.
+	// NO_ERROR means it succeeded.
+	Error         *ExpectedError `protobuf:"varint,10,opt,name=error,proto3,enum=io.oxia.okk.proto.v1.ExpectedError,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteResponse) Reset() {
+	*x = ExecuteResponse{}
+	mi := &file_okk_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteResponse) ProtoMessage() {}
+
+func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteResponse) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExecuteResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_Ok
+}
+
+func (x *ExecuteResponse) GetStatusInfo() string {
+	if x != nil {
+		return x.StatusInfo
+	}
+	return ""
+}
+
+func (x *ExecuteResponse) GetVersionId() int64 {
+	if x != nil && x.VersionId != nil {
+		return *x.VersionId
+	}
+	return 0
+}
+
+func (x *ExecuteResponse) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetVersionConflict() bool {
+	if x != nil && x.VersionConflict != nil {
+		return *x.VersionConflict
+	}
+	return false
+}
+
+func (x *ExecuteResponse) GetSequenceUpdates() []string {
+	if x != nil {
+		return x.SequenceUpdates
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetFailureDetail() *AssertionFailureDetail {
+	if x != nil {
+		return x.FailureDetail
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ExecuteResponse) GetError() ExpectedError {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ExpectedError_NO_ERROR
+}
+
+type DescribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeRequest) Reset() {
+	*x = DescribeRequest{}
+	mi := &file_okk_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeRequest) ProtoMessage() {}
+
+func (x *DescribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeRequest.ProtoReflect.Descriptor instead.
+func (*DescribeRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{18}
+}
+
+// DescribeResponse tells the coordinator what a worker supports, so that
+// testcases needing more are rejected up front.
+type DescribeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The worker implementation, e.g. "jvm".
+	Implementation string `protobuf:"bytes,1,opt,name=implementation,proto3" json:"implementation,omitempty"`
+	// The Oxia client library the worker uses, and its version.
+	ClientLibrary string `protobuf:"bytes,2,opt,name=client_library,json=clientLibrary,proto3" json:"client_library,omitempty"`
+	ClientVersion string `protobuf:"bytes,3,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	// The version of this protocol the worker implements.
+	ProtocolVersion int32 `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// The operations the worker executes, named after the Operation fields in
+	// camel case, e.g. "deleteRange", and the optional fields of the operations
+	// it supports, as "<operation>.<field>", e.g. "put.secondaryIndexes".
+	Operations []string `protobuf:"bytes,5,rep,name=operations,proto3" json:"operations,omitempty"`
+	// The assertions the worker evaluates in the WORKER mode, as
+	// "<operation>.<kind>" with kind one of records, emptyRecords,
+	// notification, versionConflict, expectedError and sequenceUpdates.
+	Assertions     []string        `protobuf:"bytes,6,rep,name=assertions,proto3" json:"assertions,omitempty"`
+	AssertionModes []AssertionMode `protobuf:"varint,7,rep,packed,name=assertion_modes,json=assertionModes,proto3,enum=io.oxia.okk.proto.v1.AssertionMode" json:"assertion_modes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DescribeResponse) Reset() {
+	*x = DescribeResponse{}
+	mi := &file_okk_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeResponse) ProtoMessage() {}
+
+func (x *DescribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeResponse.ProtoReflect.Descriptor instead.
+func (*DescribeResponse) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DescribeResponse) GetImplementation() string {
+	if x != nil {
+		return x.Implementation
+	}
+	return ""
+}
+
+func (x *DescribeResponse) GetClientLibrary() string {
+	if x != nil {
+		return x.ClientLibrary
+	}
+	return ""
+}
+
+func (x *DescribeResponse) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *DescribeResponse) GetProtocolVersion() int32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *DescribeResponse) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *DescribeResponse) GetAssertions() []string {
+	if x != nil {
+		return x.Assertions
+	}
+	return nil
+}
+
+func (x *DescribeResponse) GetAssertionModes() []AssertionMode {
+	if x != nil {
+		return x.AssertionModes
+	}
+	return nil
+}
+
+type RegisterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The endpoint the coordinator connects to the worker at.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// Labels testcases select the worker by, e.g. language, namespace or zone.
+	Labels        map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_okk_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RegisterRequest) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type RegisterResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	// The worker is deregistered when it misses three heartbeats.
+	HeartbeatIntervalMillis int64 `protobuf:"varint,2,opt,name=heartbeat_interval_millis,json=heartbeatIntervalMillis,proto3" json:"heartbeat_interval_millis,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_okk_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RegisterResponse) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetHeartbeatIntervalMillis() int64 {
+	if x != nil {
+		return x.HeartbeatIntervalMillis
+	}
+	return 0
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_okk_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HeartbeatRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+type HeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_okk_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{23}
+}
+
+type DeregisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterRequest) Reset() {
+	*x = DeregisterRequest{}
+	mi := &file_okk_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterRequest) ProtoMessage() {}
+
+func (x *DeregisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterRequest.ProtoReflect.Descriptor instead.
+func (*DeregisterRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeregisterRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+type DeregisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterResponse) Reset() {
+	*x = DeregisterResponse{}
+	mi := &file_okk_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterResponse) ProtoMessage() {}
+
+func (x *DeregisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterResponse.ProtoReflect.Descriptor instead.
+func (*DeregisterResponse) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{25}
+}
+
+// FailurePolicy mirrors the failurePolicy of the HTTP testcase config.
+type FailurePolicy struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	MaxRetries                 uint64                 `protobuf:"varint,1,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	MaxElapsed                 string                 `protobuf:"bytes,2,opt,name=max_elapsed,json=maxElapsed,proto3" json:"max_elapsed,omitempty"`
+	ContinueOnAssertionFailure bool                   `protobuf:"varint,3,opt,name=continue_on_assertion_failure,json=continueOnAssertionFailure,proto3" json:"continue_on_assertion_failure,omitempty"`
+	ResyncOnAssertionFailure   bool                   `protobuf:"varint,4,opt,name=resync_on_assertion_failure,json=resyncOnAssertionFailure,proto3" json:"resync_on_assertion_failure,omitempty"`
+	StopOnNonRetryable         bool                   `protobuf:"varint,5,opt,name=stop_on_non_retryable,json=stopOnNonRetryable,proto3" json:"stop_on_non_retryable,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *FailurePolicy) Reset() {
+	*x = FailurePolicy{}
+	mi := &file_okk_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailurePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailurePolicy) ProtoMessage() {}
+
+func (x *FailurePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailurePolicy.ProtoReflect.Descriptor instead.
+func (*FailurePolicy) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FailurePolicy) GetMaxRetries() uint64 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *FailurePolicy) GetMaxElapsed() string {
+	if x != nil {
+		return x.MaxElapsed
+	}
+	return ""
+}
+
+func (x *FailurePolicy) GetContinueOnAssertionFailure() bool {
+	if x != nil {
+		return x.ContinueOnAssertionFailure
+	}
+	return false
+}
+
+func (x *FailurePolicy) GetResyncOnAssertionFailure() bool {
+	if x != nil {
+		return x.ResyncOnAssertionFailure
+	}
+	return false
+}
+
+func (x *FailurePolicy) GetStopOnNonRetryable() bool {
+	if x != nil {
+		return x.StopOnNonRetryable
+	}
+	return false
+}
+
+// WorkerAuth mirrors the workerAuth of the HTTP testcase config.
+type WorkerAuth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tls           bool                   `protobuf:"varint,1,opt,name=tls,proto3" json:"tls,omitempty"`
+	CaFile        string                 `protobuf:"bytes,2,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+	CertFile      string                 `protobuf:"bytes,3,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	KeyFile       string                 `protobuf:"bytes,4,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	ServerName    string                 `protobuf:"bytes,5,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	TokenFile     string                 `protobuf:"bytes,6,opt,name=token_file,json=tokenFile,proto3" json:"token_file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerAuth) Reset() {
+	*x = WorkerAuth{}
+	mi := &file_okk_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerAuth) ProtoMessage() {}
+
+func (x *WorkerAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerAuth.ProtoReflect.Descriptor instead.
+func (*WorkerAuth) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WorkerAuth) GetTls() bool {
+	if x != nil {
+		return x.Tls
+	}
+	return false
+}
+
+func (x *WorkerAuth) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *WorkerAuth) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *WorkerAuth) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *WorkerAuth) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *WorkerAuth) GetTokenFile() string {
+	if x != nil {
+		return x.TokenFile
+	}
+	return ""
+}
+
+// TestCase is the configuration of a testcase, as accepted by POST /testcases.
+// Durations are in the Go format, e.g. "30s".
+type TestCase struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Namespace string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Exactly one of worker_endpoint and worker_selector is set.
+	WorkerEndpoint string            `protobuf:"bytes,4,opt,name=worker_endpoint,json=workerEndpoint,proto3" json:"worker_endpoint,omitempty"`
+	WorkerSelector map[string]string `protobuf:"bytes,5,rep,name=worker_selector,json=workerSelector,proto3" json:"worker_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OpRate         int32             `protobuf:"varint,6,opt,name=op_rate,json=opRate,proto3" json:"op_rate,omitempty"`
+	Duration       string            `protobuf:"bytes,7,opt,name=duration,proto3" json:"duration,omitempty"`
+	// "worker" or "coordinator", the worker mode when empty.
+	AssertionMode string            `protobuf:"bytes,8,opt,name=assertion_mode,json=assertionMode,proto3" json:"assertion_mode,omitempty"`
+	OpTimeout     string            `protobuf:"bytes,9,opt,name=op_timeout,json=opTimeout,proto3" json:"op_timeout,omitempty"`
+	OpTimeouts    map[string]string `protobuf:"bytes,10,rep,name=op_timeouts,json=opTimeouts,proto3" json:"op_timeouts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	FailurePolicy *FailurePolicy    `protobuf:"bytes,11,opt,name=failure_policy,json=failurePolicy,proto3,oneof" json:"failure_policy,omitempty"`
+	WorkerAuth    *WorkerAuth       `protobuf:"bytes,12,opt,name=worker_auth,json=workerAuth,proto3,oneof" json:"worker_auth,omitempty"`
+	Properties    map[string]string `protobuf:"bytes,13,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestCase) Reset() {
+	*x = TestCase{}
+	mi := &file_okk_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCase) ProtoMessage() {}
+
+func (x *TestCase) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCase.ProtoReflect.Descriptor instead.
+func (*TestCase) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TestCase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TestCase) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TestCase) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *TestCase) GetWorkerEndpoint() string {
+	if x != nil {
+		return x.WorkerEndpoint
+	}
+	return ""
+}
+
+func (x *TestCase) GetWorkerSelector() map[string]string {
+	if x != nil {
+		return x.WorkerSelector
+	}
+	return nil
+}
+
+func (x *TestCase) GetOpRate() int32 {
+	if x != nil {
+		return x.OpRate
+	}
+	return 0
+}
+
+func (x *TestCase) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *TestCase) GetAssertionMode() string {
+	if x != nil {
+		return x.AssertionMode
+	}
+	return ""
+}
+
+func (x *TestCase) GetOpTimeout() string {
+	if x != nil {
+		return x.OpTimeout
+	}
+	return ""
+}
+
+func (x *TestCase) GetOpTimeouts() map[string]string {
+	if x != nil {
+		return x.OpTimeouts
+	}
+	return nil
+}
+
+func (x *TestCase) GetFailurePolicy() *FailurePolicy {
+	if x != nil {
+		return x.FailurePolicy
+	}
+	return nil
+}
+
+func (x *TestCase) GetWorkerAuth() *WorkerAuth {
+	if x != nil {
+		return x.WorkerAuth
+	}
+	return nil
+}
+
+func (x *TestCase) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type FailedRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         *string                `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	ValueChecksum *string                `protobuf:"bytes,3,opt,name=value_checksum,json=valueChecksum,proto3,oneof" json:"value_checksum,omitempty"`
+	ValueLength   *uint64                `protobuf:"varint,4,opt,name=value_length,json=valueLength,proto3,oneof" json:"value_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailedRecord) Reset() {
+	*x = FailedRecord{}
+	mi := &file_okk_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailedRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedRecord) ProtoMessage() {}
+
+func (x *FailedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedRecord.ProtoReflect.Descriptor instead.
+func (*FailedRecord) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FailedRecord) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *FailedRecord) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+func (x *FailedRecord) GetValueChecksum() string {
+	if x != nil && x.ValueChecksum != nil {
+		return *x.ValueChecksum
+	}
+	return ""
+}
+
+func (x *FailedRecord) GetValueLength() uint64 {
+	if x != nil && x.ValueLength != nil {
+		return *x.ValueLength
+	}
+	return 0
+}
+
+// TestCaseFailure is a failed assertion of a testcase. Timestamps are in
+// Unix nanoseconds.
+type TestCaseFailure struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Sequence             int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Key                  string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Message              string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	MissingKeys          []string               `protobuf:"bytes,4,rep,name=missing_keys,json=missingKeys,proto3" json:"missing_keys,omitempty"`
+	ExtraKeys            []string               `protobuf:"bytes,5,rep,name=extra_keys,json=extraKeys,proto3" json:"extra_keys,omitempty"`
+	ValueMismatches      []string               `protobuf:"bytes,6,rep,name=value_mismatches,json=valueMismatches,proto3" json:"value_mismatches,omitempty"`
+	Mismatches           []string               `protobuf:"bytes,7,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+	ExpectedRecords      []*FailedRecord        `protobuf:"bytes,8,rep,name=expected_records,json=expectedRecords,proto3" json:"expected_records,omitempty"`
+	ActualRecords        []*FailedRecord        `protobuf:"bytes,9,rep,name=actual_records,json=actualRecords,proto3" json:"actual_records,omitempty"`
+	ExpectedNotification string                 `protobuf:"bytes,10,opt,name=expected_notification,json=expectedNotification,proto3" json:"expected_notification,omitempty"`
+	ActualNotification   string                 `protobuf:"bytes,11,opt,name=actual_notification,json=actualNotification,proto3" json:"actual_notification,omitempty"`
+	ExpectedError        string                 `protobuf:"bytes,12,opt,name=expected_error,json=expectedError,proto3" json:"expected_error,omitempty"`
+	ActualError          string                 `protobuf:"bytes,13,opt,name=actual_error,json=actualError,proto3" json:"actual_error,omitempty"`
+	OperationTimestamp   *int64                 `protobuf:"varint,14,opt,name=operation_timestamp,json=operationTimestamp,proto3,oneof" json:"operation_timestamp,omitempty"`
+	ObservedTimestamp    int64                  `protobuf:"varint,15,opt,name=observed_timestamp,json=observedTimestamp,proto3" json:"observed_timestamp,omitempty"`
+	Worker               string                 `protobuf:"bytes,16,opt,name=worker,proto3" json:"worker,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TestCaseFailure) Reset() {
+	*x = TestCaseFailure{}
+	mi := &file_okk_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestCaseFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCaseFailure) ProtoMessage() {}
+
+func (x *TestCaseFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCaseFailure.ProtoReflect.Descriptor instead.
+func (*TestCaseFailure) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TestCaseFailure) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *TestCaseFailure) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TestCaseFailure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TestCaseFailure) GetMissingKeys() []string {
+	if x != nil {
+		return x.MissingKeys
+	}
+	return nil
+}
+
+func (x *TestCaseFailure) GetExtraKeys() []string {
+	if x != nil {
+		return x.ExtraKeys
+	}
+	return nil
+}
+
+func (x *TestCaseFailure) GetValueMismatches() []string {
+	if x != nil {
+		return x.ValueMismatches
+	}
+	return nil
+}
+
+func (x *TestCaseFailure) GetMismatches() []string {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+func (x *TestCaseFailure) GetExpectedRecords() []*FailedRecord {
+	if x != nil {
+		return x.ExpectedRecords
+	}
+	return nil
+}
+
+func (x *TestCaseFailure) GetActualRecords() []*FailedRecord {
+	if x != nil {
+		return x.ActualRecords
+	}
+	return nil
+}
+
+func (x *TestCaseFailure) GetExpectedNotification() string {
+	if x != nil {
+		return x.ExpectedNotification
+	}
+	return ""
+}
+
+func (x *TestCaseFailure) GetActualNotification() string {
+	if x != nil {
+		return x.ActualNotification
+	}
+	return ""
+}
+
+func (x *TestCaseFailure) GetExpectedError() string {
+	if x != nil {
+		return x.ExpectedError
+	}
+	return ""
+}
+
+func (x *TestCaseFailure) GetActualError() string {
+	if x != nil {
+		return x.ActualError
+	}
+	return ""
+}
+
+func (x *TestCaseFailure) GetOperationTimestamp() int64 {
+	if x != nil && x.OperationTimestamp != nil {
+		return *x.OperationTimestamp
+	}
+	return 0
+}
+
+func (x *TestCaseFailure) GetObservedTimestamp() int64 {
+	if x != nil {
+		return x.ObservedTimestamp
+	}
+	return 0
+}
+
+func (x *TestCaseFailure) GetWorker() string {
+	if x != nil {
+		return x.Worker
+	}
+	return ""
+}
+
+// TestCaseStatus is the status of a testcase, as returned by
+// GET /testcases/{name}.
+type TestCaseStatus struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Namespace      string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	WorkerEndpoint string                 `protobuf:"bytes,4,opt,name=worker_endpoint,json=workerEndpoint,proto3" json:"worker_endpoint,omitempty"`
+	// "running", "completed" or "failed".
+	State                string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	StateReason          string `protobuf:"bytes,6,opt,name=state_reason,json=stateReason,proto3" json:"state_reason,omitempty"`
+	Phase                string `protobuf:"bytes,7,opt,name=phase,proto3" json:"phase,omitempty"`
+	Operations           int64  `protobuf:"varint,8,opt,name=operations,proto3" json:"operations,omitempty"`
+	AssertionsPassed     int64  `protobuf:"varint,9,opt,name=assertions_passed,json=assertionsPassed,proto3" json:"assertions_passed,omitempty"`
+	AssertionsFailed     int64  `protobuf:"varint,10,opt,name=assertions_failed,json=assertionsFailed,proto3" json:"assertions_failed,omitempty"`
+	Timeouts             int64  `protobuf:"varint,11,opt,name=timeouts,proto3" json:"timeouts,omitempty"`
+	Retries              int64  `protobuf:"varint,12,opt,name=retries,proto3" json:"retries,omitempty"`
+	NonRetryableFailures int64  `protobuf:"varint,13,opt,name=non_retryable_failures,json=nonRetryableFailures,proto3" json:"non_retryable_failures,omitempty"`
+	Resyncs              int64  `protobuf:"varint,14,opt,name=resyncs,proto3" json:"resyncs,omitempty"`
+	// Unix nanoseconds.
+	RunningSince  *int64             `protobuf:"varint,15,opt,name=running_since,json=runningSince,proto3,oneof" json:"running_since,omitempty"`
+	LastFailure   *string            `protobuf:"bytes,16,opt,name=last_failure,json=lastFailure,proto3,oneof" json:"last_failure,omitempty"`
+	Failures      []*TestCaseFailure `protobuf:"bytes,17,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestCaseStatus) Reset() {
+	*x = TestCaseStatus{}
+	mi := &file_okk_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestCaseStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCaseStatus) ProtoMessage() {}
+
+func (x *TestCaseStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCaseStatus.ProtoReflect.Descriptor instead.
+func (*TestCaseStatus) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TestCaseStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TestCaseStatus) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TestCaseStatus) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *TestCaseStatus) GetWorkerEndpoint() string {
+	if x != nil {
+		return x.WorkerEndpoint
+	}
+	return ""
 }
 
-func (x *Assertion) Reset() {
-	*x = Assertion{}
-	mi := &file_okk_proto_msgTypes[11]
+func (x *TestCaseStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TestCaseStatus) GetStateReason() string {
+	if x != nil {
+		return x.StateReason
+	}
+	return ""
+}
+
+func (x *TestCaseStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *TestCaseStatus) GetOperations() int64 {
+	if x != nil {
+		return x.Operations
+	}
+	return 0
+}
+
+func (x *TestCaseStatus) GetAssertionsPassed() int64 {
+	if x != nil {
+		return x.AssertionsPassed
+	}
+	return 0
+}
+
+func (x *TestCaseStatus) GetAssertionsFailed() int64 {
+	if x != nil {
+		return x.AssertionsFailed
+	}
+	return 0
+}
+
+func (x *TestCaseStatus) GetTimeouts() int64 {
+	if x != nil {
+		return x.Timeouts
+	}
+	return 0
+}
+
+func (x *TestCaseStatus) GetRetries() int64 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *TestCaseStatus) GetNonRetryableFailures() int64 {
+	if x != nil {
+		return x.NonRetryableFailures
+	}
+	return 0
+}
+
+func (x *TestCaseStatus) GetResyncs() int64 {
+	if x != nil {
+		return x.Resyncs
+	}
+	return 0
+}
+
+func (x *TestCaseStatus) GetRunningSince() int64 {
+	if x != nil && x.RunningSince != nil {
+		return *x.RunningSince
+	}
+	return 0
+}
+
+func (x *TestCaseStatus) GetLastFailure() string {
+	if x != nil && x.LastFailure != nil {
+		return *x.LastFailure
+	}
+	return ""
+}
+
+func (x *TestCaseStatus) GetFailures() []*TestCaseFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type CreateTestCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestCase      *TestCase              `protobuf:"bytes,1,opt,name=test_case,json=testCase,proto3" json:"test_case,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTestCaseRequest) Reset() {
+	*x = CreateTestCaseRequest{}
+	mi := &file_okk_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Assertion) String() string {
+func (x *CreateTestCaseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Assertion) ProtoMessage() {}
+func (*CreateTestCaseRequest) ProtoMessage() {}
 
-func (x *Assertion) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[11]
+func (x *CreateTestCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,77 +2913,120 @@ func (x *Assertion) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Assertion.ProtoReflect.Descriptor instead.
-func (*Assertion) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CreateTestCaseRequest.ProtoReflect.Descriptor instead.
+func (*CreateTestCaseRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *Assertion) GetEventuallyEmpty() bool {
-	if x != nil && x.EventuallyEmpty != nil {
-		return *x.EventuallyEmpty
+func (x *CreateTestCaseRequest) GetTestCase() *TestCase {
+	if x != nil {
+		return x.TestCase
 	}
-	return false
+	return nil
 }
 
-func (x *Assertion) GetEmptyRecords() bool {
-	if x != nil && x.EmptyRecords != nil {
-		return *x.EmptyRecords
-	}
-	return false
+type ListTestCasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Assertion) GetPartitionKey() string {
-	if x != nil && x.PartitionKey != nil {
-		return *x.PartitionKey
-	}
-	return ""
+func (x *ListTestCasesRequest) Reset() {
+	*x = ListTestCasesRequest{}
+	mi := &file_okk_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Assertion) GetRecords() []*Record {
+func (x *ListTestCasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTestCasesRequest) ProtoMessage() {}
+
+func (x *ListTestCasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[33]
 	if x != nil {
-		return x.Records
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Assertion) GetNotification() *Notification {
+// Deprecated: Use ListTestCasesRequest.ProtoReflect.Descriptor instead.
+func (*ListTestCasesRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{33}
+}
+
+type ListTestCasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestCases     []*TestCaseStatus      `protobuf:"bytes,1,rep,name=test_cases,json=testCases,proto3" json:"test_cases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTestCasesResponse) Reset() {
+	*x = ListTestCasesResponse{}
+	mi := &file_okk_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTestCasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTestCasesResponse) ProtoMessage() {}
+
+func (x *ListTestCasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[34]
 	if x != nil {
-		return x.Notification
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Assertion) GetExpectVersionConflict() bool {
-	if x != nil && x.ExpectVersionConflict != nil {
-		return *x.ExpectVersionConflict
+// Deprecated: Use ListTestCasesResponse.ProtoReflect.Descriptor instead.
+func (*ListTestCasesResponse) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListTestCasesResponse) GetTestCases() []*TestCaseStatus {
+	if x != nil {
+		return x.TestCases
 	}
-	return false
+	return nil
 }
 
-type ExecuteCommand struct {
+type GetTestCaseRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Testcase      string                 `protobuf:"bytes,1,opt,name=testcase,proto3" json:"testcase,omitempty"`
-	Operation     *Operation             `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
-	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecuteCommand) Reset() {
-	*x = ExecuteCommand{}
-	mi := &file_okk_proto_msgTypes[12]
+func (x *GetTestCaseRequest) Reset() {
+	*x = GetTestCaseRequest{}
+	mi := &file_okk_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecuteCommand) String() string {
+func (x *GetTestCaseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecuteCommand) ProtoMessage() {}
+func (*GetTestCaseRequest) ProtoMessage() {}
 
-func (x *ExecuteCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[12]
+func (x *GetTestCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1036,56 +3037,83 @@ func (x *ExecuteCommand) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecuteCommand.ProtoReflect.Descriptor instead.
-func (*ExecuteCommand) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetTestCaseRequest.ProtoReflect.Descriptor instead.
+func (*GetTestCaseRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ExecuteCommand) GetTestcase() string {
+func (x *GetTestCaseRequest) GetName() string {
 	if x != nil {
-		return x.Testcase
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ExecuteCommand) GetOperation() *Operation {
+type DeleteTestCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTestCaseRequest) Reset() {
+	*x = DeleteTestCaseRequest{}
+	mi := &file_okk_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTestCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTestCaseRequest) ProtoMessage() {}
+
+func (x *DeleteTestCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[36]
 	if x != nil {
-		return x.Operation
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *ExecuteCommand) GetNamespace() string {
+// Deprecated: Use DeleteTestCaseRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTestCaseRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteTestCaseRequest) GetName() string {
 	if x != nil {
-		return x.Namespace
+		return x.Name
 	}
 	return ""
 }
 
-type ExecuteResponse struct {
+type DeleteTestCaseResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=io.oxia.okk.proto.v1.Status" json:"status,omitempty"`
-	StatusInfo    string                 `protobuf:"bytes,2,opt,name=status_info,json=statusInfo,proto3" json:"status_info,omitempty"`
-	VersionId     *int64                 `protobuf:"varint,3,opt,name=version_id,json=versionId,proto3,oneof" json:"version_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecuteResponse) Reset() {
-	*x = ExecuteResponse{}
-	mi := &file_okk_proto_msgTypes[13]
+func (x *DeleteTestCaseResponse) Reset() {
+	*x = DeleteTestCaseResponse{}
+	mi := &file_okk_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecuteResponse) String() string {
+func (x *DeleteTestCaseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecuteResponse) ProtoMessage() {}
+func (*DeleteTestCaseResponse) ProtoMessage() {}
 
-func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_okk_proto_msgTypes[13]
+func (x *DeleteTestCaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1096,30 +3124,53 @@ func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
-func (*ExecuteResponse) Descriptor() ([]byte, []int) {
-	return file_okk_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use DeleteTestCaseResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTestCaseResponse) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ExecuteResponse) GetStatus() Status {
-	if x != nil {
-		return x.Status
-	}
-	return Status_Ok
+type WatchTestCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecuteResponse) GetStatusInfo() string {
+func (x *WatchTestCaseRequest) Reset() {
+	*x = WatchTestCaseRequest{}
+	mi := &file_okk_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTestCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTestCaseRequest) ProtoMessage() {}
+
+func (x *WatchTestCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_okk_proto_msgTypes[38]
 	if x != nil {
-		return x.StatusInfo
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ExecuteResponse) GetVersionId() int64 {
-	if x != nil && x.VersionId != nil {
-		return *x.VersionId
+// Deprecated: Use WatchTestCaseRequest.ProtoReflect.Descriptor instead.
+func (*WatchTestCaseRequest) Descriptor() ([]byte, []int) {
+	return file_okk_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *WatchTestCaseRequest) GetName() string {
+	if x != nil {
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
 var File_okk_proto protoreflect.FileDescriptor
@@ -1127,22 +3178,33 @@ var File_okk_proto protoreflect.FileDescriptor
 const file_okk_proto_rawDesc = "" +
 	"\n" +
 	"\tokk.proto\x12\x14io.oxia.okk.proto.v1\"\x19\n" +
-	"\x17OperationSessionRestart\"\x8b\x02\n" +
+	"\x17OperationSessionRestart\"T\n" +
+	"\x0eSecondaryIndex\x12\x1d\n" +
+	"\n" +
+	"index_name\x18\x01 \x01(\tR\tindexName\x12#\n" +
+	"\rsecondary_key\x18\x02 \x01(\tR\fsecondaryKey\"\xde\x02\n" +
 	"\fOperationPut\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\fR\x05value\x12\x1c\n" +
 	"\tephemeral\x18\x03 \x01(\bR\tephemeral\x12(\n" +
 	"\rpartition_key\x18\x04 \x01(\tH\x00R\fpartitionKey\x88\x01\x01\x12,\n" +
 	"\x12sequence_key_delta\x18\x05 \x03(\x04R\x10sequenceKeyDelta\x123\n" +
-	"\x13expected_version_id\x18\x06 \x01(\x03H\x01R\x11expectedVersionId\x88\x01\x01B\x10\n" +
+	"\x13expected_version_id\x18\x06 \x01(\x03H\x01R\x11expectedVersionId\x88\x01\x01\x12Q\n" +
+	"\x11secondary_indexes\x18\a \x03(\v2$.io.oxia.okk.proto.v1.SecondaryIndexR\x10secondaryIndexesB\x10\n" +
 	"\x0e_partition_keyB\x16\n" +
-	"\x14_expected_version_id\"r\n" +
+	"\x14_expected_version_id\"\xa2\x01\n" +
 	"\fOperationGet\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12P\n" +
-	"\x0fcomparison_type\x18\x02 \x01(\x0e2'.io.oxia.okk.proto.v1.KeyComparisonTypeR\x0ecomparisonType\"E\n" +
+	"\x0fcomparison_type\x18\x02 \x01(\x0e2'.io.oxia.okk.proto.v1.KeyComparisonTypeR\x0ecomparisonType\x12 \n" +
+	"\tuse_index\x18\x03 \x01(\tH\x00R\buseIndex\x88\x01\x01B\f\n" +
+	"\n" +
+	"_use_index\"u\n" +
 	"\rOperationList\x12\x1b\n" +
 	"\tkey_start\x18\x01 \x01(\tR\bkeyStart\x12\x17\n" +
-	"\akey_end\x18\x02 \x01(\tR\x06keyEnd\"E\n" +
+	"\akey_end\x18\x02 \x01(\tR\x06keyEnd\x12 \n" +
+	"\tuse_index\x18\x03 \x01(\tH\x00R\buseIndex\x88\x01\x01B\f\n" +
+	"\n" +
+	"_use_index\"E\n" +
 	"\rOperationScan\x12\x1b\n" +
 	"\tkey_start\x18\x01 \x01(\tR\bkeyStart\x12\x17\n" +
 	"\akey_end\x18\x02 \x01(\tR\x06keyEnd\"#\n" +
@@ -1150,7 +3212,11 @@ const file_okk_proto_rawDesc = "" +
 	"\x03key\x18\x01 \x01(\tR\x03key\"L\n" +
 	"\x14OperationDeleteRange\x12\x1b\n" +
 	"\tkey_start\x18\x01 \x01(\tR\bkeyStart\x12\x17\n" +
-	"\akey_end\x18\x02 \x01(\tR\x06keyEnd\"\xd4\x05\n" +
+	"\akey_end\x18\x02 \x01(\tR\x06keyEnd\"\x93\x01\n" +
+	"\x18OperationSequenceUpdates\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12#\n" +
+	"\rpartition_key\x18\x02 \x01(\tR\fpartitionKey\x12@\n" +
+	"\x06action\x18\x03 \x01(\x0e2(.io.oxia.okk.proto.v1.SubscriptionActionR\x06action\"\xb1\x06\n" +
 	"\tOperation\x12\x1a\n" +
 	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12B\n" +
 	"\tassertion\x18\x02 \x01(\v2\x1f.io.oxia.okk.proto.v1.AssertionH\x01R\tassertion\x88\x01\x01\x12K\n" +
@@ -1162,7 +3228,8 @@ const file_okk_proto_rawDesc = "" +
 	"\x04scan\x18\b \x01(\v2#.io.oxia.okk.proto.v1.OperationScanH\x00R\x04scan\x12X\n" +
 	"\x0fsession_restart\x18\t \x01(\v2-.io.oxia.okk.proto.v1.OperationSessionRestartH\x00R\x0esessionRestart\x12O\n" +
 	"\fdelete_range\x18\n" +
-	" \x01(\v2*.io.oxia.okk.proto.v1.OperationDeleteRangeH\x00R\vdeleteRange\x12\x1c\n" +
+	" \x01(\v2*.io.oxia.okk.proto.v1.OperationDeleteRangeH\x00R\vdeleteRange\x12[\n" +
+	"\x10sequence_updates\x18\v \x01(\v2..io.oxia.okk.proto.v1.OperationSequenceUpdatesH\x00R\x0fsequenceUpdates\x12\x1c\n" +
 	"\ttimestamp\x18d \x01(\x03R\ttimestampB\v\n" +
 	"\toperationB\f\n" +
 	"\n" +
@@ -1182,52 +3249,270 @@ const file_okk_proto_rawDesc = "" +
 	"\n" +
 	"_key_startB\n" +
 	"\n" +
-	"\b_key_end\"0\n" +
+	"\b_key_end\"\xdb\x01\n" +
 	"\x06Record\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value\"\xb7\x03\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12*\n" +
+	"\x0evalue_checksum\x18\x03 \x01(\aH\x00R\rvalueChecksum\x88\x01\x01\x12&\n" +
+	"\fvalue_length\x18\x04 \x01(\x04H\x01R\vvalueLength\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"version_id\x18\x05 \x01(\x03H\x02R\tversionId\x88\x01\x01B\x11\n" +
+	"\x0f_value_checksumB\x0f\n" +
+	"\r_value_lengthB\r\n" +
+	"\v_version_id\"e\n" +
+	"\n" +
+	"Eventually\x12%\n" +
+	"\x0etimeout_millis\x18\x01 \x01(\x03R\rtimeoutMillis\x120\n" +
+	"\x14poll_interval_millis\x18\x02 \x01(\x03R\x12pollIntervalMillis\"\x9c\x05\n" +
 	"\tAssertion\x12.\n" +
 	"\x10eventually_empty\x18\x01 \x01(\bH\x00R\x0feventuallyEmpty\x88\x01\x01\x12(\n" +
 	"\rempty_records\x18\x02 \x01(\bH\x01R\femptyRecords\x88\x01\x01\x12(\n" +
 	"\rpartition_key\x18\x03 \x01(\tH\x02R\fpartitionKey\x88\x01\x01\x126\n" +
 	"\arecords\x18\x04 \x03(\v2\x1c.io.oxia.okk.proto.v1.RecordR\arecords\x12K\n" +
 	"\fnotification\x18\x05 \x01(\v2\".io.oxia.okk.proto.v1.NotificationH\x03R\fnotification\x88\x01\x01\x12;\n" +
-	"\x17expect_version_conflict\x18\x06 \x01(\bH\x04R\x15expectVersionConflict\x88\x01\x01B\x13\n" +
+	"\x17expect_version_conflict\x18\x06 \x01(\bH\x04R\x15expectVersionConflict\x88\x01\x01\x12)\n" +
+	"\x10sequence_updates\x18\a \x03(\tR\x0fsequenceUpdates\x12E\n" +
+	"\n" +
+	"eventually\x18\b \x01(\v2 .io.oxia.okk.proto.v1.EventuallyH\x05R\n" +
+	"eventually\x88\x01\x01\x12O\n" +
+	"\x0eexpected_error\x18\t \x01(\x0e2#.io.oxia.okk.proto.v1.ExpectedErrorH\x06R\rexpectedError\x88\x01\x01B\x13\n" +
 	"\x11_eventually_emptyB\x10\n" +
 	"\x0e_empty_recordsB\x10\n" +
 	"\x0e_partition_keyB\x0f\n" +
 	"\r_notificationB\x1a\n" +
-	"\x18_expect_version_conflict\"\x89\x01\n" +
+	"\x18_expect_version_conflictB\r\n" +
+	"\v_eventuallyB\x11\n" +
+	"\x0f_expected_error\"\xd5\x01\n" +
 	"\x0eExecuteCommand\x12\x1a\n" +
 	"\btestcase\x18\x01 \x01(\tR\btestcase\x12=\n" +
 	"\toperation\x18\x02 \x01(\v2\x1f.io.oxia.okk.proto.v1.OperationR\toperation\x12\x1c\n" +
-	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\x9b\x01\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12J\n" +
+	"\x0eassertion_mode\x18\x04 \x01(\x0e2#.io.oxia.okk.proto.v1.AssertionModeR\rassertionMode\"\x97\x06\n" +
+	"\x16AssertionFailureDetail\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12G\n" +
+	"\x10expected_records\x18\x04 \x03(\v2\x1c.io.oxia.okk.proto.v1.RecordR\x0fexpectedRecords\x12C\n" +
+	"\x0eactual_records\x18\x05 \x03(\v2\x1c.io.oxia.okk.proto.v1.RecordR\ractualRecords\x12\\\n" +
+	"\x15expected_notification\x18\x06 \x01(\v2\".io.oxia.okk.proto.v1.NotificationH\x00R\x14expectedNotification\x88\x01\x01\x12X\n" +
+	"\x13actual_notification\x18\a \x01(\v2\".io.oxia.okk.proto.v1.NotificationH\x01R\x12actualNotification\x88\x01\x01\x12/\n" +
+	"\x13operation_timestamp\x18\b \x01(\x03R\x12operationTimestamp\x12-\n" +
+	"\x12observed_timestamp\x18\t \x01(\x03R\x11observedTimestamp\x12\x1b\n" +
+	"\tworker_id\x18\n" +
+	" \x01(\tR\bworkerId\x12O\n" +
+	"\x0eexpected_error\x18\v \x01(\x0e2#.io.oxia.okk.proto.v1.ExpectedErrorH\x02R\rexpectedError\x88\x01\x01\x12K\n" +
+	"\factual_error\x18\f \x01(\x0e2#.io.oxia.okk.proto.v1.ExpectedErrorH\x03R\vactualError\x88\x01\x01B\x18\n" +
+	"\x16_expected_notificationB\x16\n" +
+	"\x14_actual_notificationB\x11\n" +
+	"\x0f_expected_errorB\x0f\n" +
+	"\r_actual_error\"\xf4\x04\n" +
 	"\x0fExecuteResponse\x124\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x1c.io.oxia.okk.proto.v1.StatusR\x06status\x12\x1f\n" +
 	"\vstatus_info\x18\x02 \x01(\tR\n" +
 	"statusInfo\x12\"\n" +
 	"\n" +
-	"version_id\x18\x03 \x01(\x03H\x00R\tversionId\x88\x01\x01B\r\n" +
-	"\v_version_id*M\n" +
+	"version_id\x18\x03 \x01(\x03H\x00R\tversionId\x88\x01\x01\x126\n" +
+	"\arecords\x18\x04 \x03(\v2\x1c.io.oxia.okk.proto.v1.RecordR\arecords\x12K\n" +
+	"\fnotification\x18\x05 \x01(\v2\".io.oxia.okk.proto.v1.NotificationH\x01R\fnotification\x88\x01\x01\x12.\n" +
+	"\x10version_conflict\x18\x06 \x01(\bH\x02R\x0fversionConflict\x88\x01\x01\x12)\n" +
+	"\x10sequence_updates\x18\a \x03(\tR\x0fsequenceUpdates\x12X\n" +
+	"\x0efailure_detail\x18\b \x01(\v2,.io.oxia.okk.proto.v1.AssertionFailureDetailH\x03R\rfailureDetail\x88\x01\x01\x12\x1a\n" +
+	"\bsequence\x18\t \x01(\x03R\bsequence\x12>\n" +
+	"\x05error\x18\n" +
+	" \x01(\x0e2#.io.oxia.okk.proto.v1.ExpectedErrorH\x04R\x05error\x88\x01\x01B\r\n" +
+	"\v_version_idB\x0f\n" +
+	"\r_notificationB\x13\n" +
+	"\x11_version_conflictB\x11\n" +
+	"\x0f_failure_detailB\b\n" +
+	"\x06_error\"\x11\n" +
+	"\x0fDescribeRequest\"\xc1\x02\n" +
+	"\x10DescribeResponse\x12&\n" +
+	"\x0eimplementation\x18\x01 \x01(\tR\x0eimplementation\x12%\n" +
+	"\x0eclient_library\x18\x02 \x01(\tR\rclientLibrary\x12%\n" +
+	"\x0eclient_version\x18\x03 \x01(\tR\rclientVersion\x12)\n" +
+	"\x10protocol_version\x18\x04 \x01(\x05R\x0fprotocolVersion\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x05 \x03(\tR\n" +
+	"operations\x12\x1e\n" +
+	"\n" +
+	"assertions\x18\x06 \x03(\tR\n" +
+	"assertions\x12L\n" +
+	"\x0fassertion_modes\x18\a \x03(\x0e2#.io.oxia.okk.proto.v1.AssertionModeR\x0eassertionModes\"\xb3\x01\n" +
+	"\x0fRegisterRequest\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12I\n" +
+	"\x06labels\x18\x02 \x03(\v21.io.oxia.okk.proto.v1.RegisterRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"k\n" +
+	"\x10RegisterResponse\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12:\n" +
+	"\x19heartbeat_interval_millis\x18\x02 \x01(\x03R\x17heartbeatIntervalMillis\"/\n" +
+	"\x10HeartbeatRequest\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\"\x13\n" +
+	"\x11HeartbeatResponse\"0\n" +
+	"\x11DeregisterRequest\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\"\x14\n" +
+	"\x12DeregisterResponse\"\x86\x02\n" +
+	"\rFailurePolicy\x12\x1f\n" +
+	"\vmax_retries\x18\x01 \x01(\x04R\n" +
+	"maxRetries\x12\x1f\n" +
+	"\vmax_elapsed\x18\x02 \x01(\tR\n" +
+	"maxElapsed\x12A\n" +
+	"\x1dcontinue_on_assertion_failure\x18\x03 \x01(\bR\x1acontinueOnAssertionFailure\x12=\n" +
+	"\x1bresync_on_assertion_failure\x18\x04 \x01(\bR\x18resyncOnAssertionFailure\x121\n" +
+	"\x15stop_on_non_retryable\x18\x05 \x01(\bR\x12stopOnNonRetryable\"\xaf\x01\n" +
+	"\n" +
+	"WorkerAuth\x12\x10\n" +
+	"\x03tls\x18\x01 \x01(\bR\x03tls\x12\x17\n" +
+	"\aca_file\x18\x02 \x01(\tR\x06caFile\x12\x1b\n" +
+	"\tcert_file\x18\x03 \x01(\tR\bcertFile\x12\x19\n" +
+	"\bkey_file\x18\x04 \x01(\tR\akeyFile\x12\x1f\n" +
+	"\vserver_name\x18\x05 \x01(\tR\n" +
+	"serverName\x12\x1d\n" +
+	"\n" +
+	"token_file\x18\x06 \x01(\tR\ttokenFile\"\xef\x06\n" +
+	"\bTestCase\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12'\n" +
+	"\x0fworker_endpoint\x18\x04 \x01(\tR\x0eworkerEndpoint\x12[\n" +
+	"\x0fworker_selector\x18\x05 \x03(\v22.io.oxia.okk.proto.v1.TestCase.WorkerSelectorEntryR\x0eworkerSelector\x12\x17\n" +
+	"\aop_rate\x18\x06 \x01(\x05R\x06opRate\x12\x1a\n" +
+	"\bduration\x18\a \x01(\tR\bduration\x12%\n" +
+	"\x0eassertion_mode\x18\b \x01(\tR\rassertionMode\x12\x1d\n" +
+	"\n" +
+	"op_timeout\x18\t \x01(\tR\topTimeout\x12O\n" +
+	"\vop_timeouts\x18\n" +
+	" \x03(\v2..io.oxia.okk.proto.v1.TestCase.OpTimeoutsEntryR\n" +
+	"opTimeouts\x12O\n" +
+	"\x0efailure_policy\x18\v \x01(\v2#.io.oxia.okk.proto.v1.FailurePolicyH\x00R\rfailurePolicy\x88\x01\x01\x12F\n" +
+	"\vworker_auth\x18\f \x01(\v2 .io.oxia.okk.proto.v1.WorkerAuthH\x01R\n" +
+	"workerAuth\x88\x01\x01\x12N\n" +
+	"\n" +
+	"properties\x18\r \x03(\v2..io.oxia.okk.proto.v1.TestCase.PropertiesEntryR\n" +
+	"properties\x1aA\n" +
+	"\x13WorkerSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
+	"\x0fOpTimeoutsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
+	"\x0fPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x11\n" +
+	"\x0f_failure_policyB\x0e\n" +
+	"\f_worker_auth\"\xbd\x01\n" +
+	"\fFailedRecord\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x19\n" +
+	"\x05value\x18\x02 \x01(\tH\x00R\x05value\x88\x01\x01\x12*\n" +
+	"\x0evalue_checksum\x18\x03 \x01(\tH\x01R\rvalueChecksum\x88\x01\x01\x12&\n" +
+	"\fvalue_length\x18\x04 \x01(\x04H\x02R\vvalueLength\x88\x01\x01B\b\n" +
+	"\x06_valueB\x11\n" +
+	"\x0f_value_checksumB\x0f\n" +
+	"\r_value_length\"\xc5\x05\n" +
+	"\x0fTestCaseFailure\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12!\n" +
+	"\fmissing_keys\x18\x04 \x03(\tR\vmissingKeys\x12\x1d\n" +
+	"\n" +
+	"extra_keys\x18\x05 \x03(\tR\textraKeys\x12)\n" +
+	"\x10value_mismatches\x18\x06 \x03(\tR\x0fvalueMismatches\x12\x1e\n" +
+	"\n" +
+	"mismatches\x18\a \x03(\tR\n" +
+	"mismatches\x12M\n" +
+	"\x10expected_records\x18\b \x03(\v2\".io.oxia.okk.proto.v1.FailedRecordR\x0fexpectedRecords\x12I\n" +
+	"\x0eactual_records\x18\t \x03(\v2\".io.oxia.okk.proto.v1.FailedRecordR\ractualRecords\x123\n" +
+	"\x15expected_notification\x18\n" +
+	" \x01(\tR\x14expectedNotification\x12/\n" +
+	"\x13actual_notification\x18\v \x01(\tR\x12actualNotification\x12%\n" +
+	"\x0eexpected_error\x18\f \x01(\tR\rexpectedError\x12!\n" +
+	"\factual_error\x18\r \x01(\tR\vactualError\x124\n" +
+	"\x13operation_timestamp\x18\x0e \x01(\x03H\x00R\x12operationTimestamp\x88\x01\x01\x12-\n" +
+	"\x12observed_timestamp\x18\x0f \x01(\x03R\x11observedTimestamp\x12\x16\n" +
+	"\x06worker\x18\x10 \x01(\tR\x06workerB\x16\n" +
+	"\x14_operation_timestamp\"\x86\x05\n" +
+	"\x0eTestCaseStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12'\n" +
+	"\x0fworker_endpoint\x18\x04 \x01(\tR\x0eworkerEndpoint\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12!\n" +
+	"\fstate_reason\x18\x06 \x01(\tR\vstateReason\x12\x14\n" +
+	"\x05phase\x18\a \x01(\tR\x05phase\x12\x1e\n" +
+	"\n" +
+	"operations\x18\b \x01(\x03R\n" +
+	"operations\x12+\n" +
+	"\x11assertions_passed\x18\t \x01(\x03R\x10assertionsPassed\x12+\n" +
+	"\x11assertions_failed\x18\n" +
+	" \x01(\x03R\x10assertionsFailed\x12\x1a\n" +
+	"\btimeouts\x18\v \x01(\x03R\btimeouts\x12\x18\n" +
+	"\aretries\x18\f \x01(\x03R\aretries\x124\n" +
+	"\x16non_retryable_failures\x18\r \x01(\x03R\x14nonRetryableFailures\x12\x18\n" +
+	"\aresyncs\x18\x0e \x01(\x03R\aresyncs\x12(\n" +
+	"\rrunning_since\x18\x0f \x01(\x03H\x00R\frunningSince\x88\x01\x01\x12&\n" +
+	"\flast_failure\x18\x10 \x01(\tH\x01R\vlastFailure\x88\x01\x01\x12A\n" +
+	"\bfailures\x18\x11 \x03(\v2%.io.oxia.okk.proto.v1.TestCaseFailureR\bfailuresB\x10\n" +
+	"\x0e_running_sinceB\x0f\n" +
+	"\r_last_failure\"T\n" +
+	"\x15CreateTestCaseRequest\x12;\n" +
+	"\ttest_case\x18\x01 \x01(\v2\x1e.io.oxia.okk.proto.v1.TestCaseR\btestCase\"\x16\n" +
+	"\x14ListTestCasesRequest\"\\\n" +
+	"\x15ListTestCasesResponse\x12C\n" +
+	"\n" +
+	"test_cases\x18\x01 \x03(\v2$.io.oxia.okk.proto.v1.TestCaseStatusR\ttestCases\"(\n" +
+	"\x12GetTestCaseRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"+\n" +
+	"\x15DeleteTestCaseRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x18\n" +
+	"\x16DeleteTestCaseResponse\"*\n" +
+	"\x14WatchTestCaseRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name*M\n" +
 	"\x11KeyComparisonType\x12\t\n" +
 	"\x05EQUAL\x10\x00\x12\t\n" +
 	"\x05FLOOR\x10\x01\x12\v\n" +
 	"\aCEILING\x10\x02\x12\t\n" +
 	"\x05LOWER\x10\x03\x12\n" +
 	"\n" +
-	"\x06HIGHER\x10\x04*]\n" +
+	"\x06HIGHER\x10\x04*4\n" +
+	"\x12SubscriptionAction\x12\r\n" +
+	"\tSUBSCRIBE\x10\x00\x12\x0f\n" +
+	"\vUNSUBSCRIBE\x10\x01*]\n" +
 	"\x10NotificationType\x12\x0f\n" +
 	"\vKEY_CREATED\x10\x00\x12\x10\n" +
 	"\fKEY_MODIFIED\x10\x01\x12\x0f\n" +
 	"\vKEY_DELETED\x10\x02\x12\x15\n" +
-	"\x11KEY_RANGE_DELETED\x10\x03*U\n" +
+	"\x11KEY_RANGE_DELETED\x10\x03*\x85\x01\n" +
+	"\rExpectedError\x12\f\n" +
+	"\bNO_ERROR\x10\x00\x12\x11\n" +
+	"\rKEY_NOT_FOUND\x10\x01\x12\x14\n" +
+	"\x10VERSION_CONFLICT\x10\x02\x12\x13\n" +
+	"\x0fSESSION_EXPIRED\x10\x03\x12\x11\n" +
+	"\rINVALID_RANGE\x10\x04\x12\x15\n" +
+	"\x11REQUEST_TOO_LARGE\x10\x05*,\n" +
+	"\rAssertionMode\x12\n" +
+	"\n" +
+	"\x06WORKER\x10\x00\x12\x0f\n" +
+	"\vCOORDINATOR\x10\x01*U\n" +
 	"\x06Status\x12\x06\n" +
 	"\x02Ok\x10\x00\x12\x14\n" +
 	"\x10AssertionFailure\x10\x01\x12\x14\n" +
 	"\x10RetryableFailure\x10\x02\x12\x17\n" +
-	"\x13NonRetryableFailure\x10\x032a\n" +
+	"\x13NonRetryableFailure\x10\x032\xbc\x01\n" +
 	"\x03Okk\x12Z\n" +
-	"\aExecute\x12$.io.oxia.okk.proto.v1.ExecuteCommand\x1a%.io.oxia.okk.proto.v1.ExecuteResponse(\x010\x01B P\x01Z\x1cgithub.com/oxia-db/okk/protob\x06proto3"
+	"\aExecute\x12$.io.oxia.okk.proto.v1.ExecuteCommand\x1a%.io.oxia.okk.proto.v1.ExecuteResponse(\x010\x01\x12Y\n" +
+	"\bDescribe\x12%.io.oxia.okk.proto.v1.DescribeRequest\x1a&.io.oxia.okk.proto.v1.DescribeResponse2\xa7\x02\n" +
+	"\vOkkRegistry\x12Y\n" +
+	"\bRegister\x12%.io.oxia.okk.proto.v1.RegisterRequest\x1a&.io.oxia.okk.proto.v1.RegisterResponse\x12\\\n" +
+	"\tHeartbeat\x12&.io.oxia.okk.proto.v1.HeartbeatRequest\x1a'.io.oxia.okk.proto.v1.HeartbeatResponse\x12_\n" +
+	"\n" +
+	"Deregister\x12'.io.oxia.okk.proto.v1.DeregisterRequest\x1a(.io.oxia.okk.proto.v1.DeregisterResponse2\x8a\x04\n" +
+	"\bOkkAdmin\x12c\n" +
+	"\x0eCreateTestCase\x12+.io.oxia.okk.proto.v1.CreateTestCaseRequest\x1a$.io.oxia.okk.proto.v1.TestCaseStatus\x12h\n" +
+	"\rListTestCases\x12*.io.oxia.okk.proto.v1.ListTestCasesRequest\x1a+.io.oxia.okk.proto.v1.ListTestCasesResponse\x12]\n" +
+	"\vGetTestCase\x12(.io.oxia.okk.proto.v1.GetTestCaseRequest\x1a$.io.oxia.okk.proto.v1.TestCaseStatus\x12k\n" +
+	"\x0eDeleteTestCase\x12+.io.oxia.okk.proto.v1.DeleteTestCaseRequest\x1a,.io.oxia.okk.proto.v1.DeleteTestCaseResponse\x12c\n" +
+	"\rWatchTestCase\x12*.io.oxia.okk.proto.v1.WatchTestCaseRequest\x1a$.io.oxia.okk.proto.v1.TestCaseStatus0\x01B P\x01Z\x1cgithub.com/oxia-db/okk/protob\x06proto3"
 
 var (
 	file_okk_proto_rawDescOnce sync.Once
@@ -1241,50 +3526,128 @@ func file_okk_proto_rawDescGZIP() []byte {
 	return file_okk_proto_rawDescData
 }
 
-var file_okk_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_okk_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_okk_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_okk_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_okk_proto_goTypes = []any{
-	(KeyComparisonType)(0),          // 0: io.oxia.okk.proto.v1.KeyComparisonType
-	(NotificationType)(0),           // 1: io.oxia.okk.proto.v1.NotificationType
-	(Status)(0),                     // 2: io.oxia.okk.proto.v1.Status
-	(*OperationSessionRestart)(nil), // 3: io.oxia.okk.proto.v1.OperationSessionRestart
-	(*OperationPut)(nil),            // 4: io.oxia.okk.proto.v1.OperationPut
-	(*OperationGet)(nil),            // 5: io.oxia.okk.proto.v1.OperationGet
-	(*OperationList)(nil),           // 6: io.oxia.okk.proto.v1.OperationList
-	(*OperationScan)(nil),           // 7: io.oxia.okk.proto.v1.OperationScan
-	(*OperationDelete)(nil),         // 8: io.oxia.okk.proto.v1.OperationDelete
-	(*OperationDeleteRange)(nil),    // 9: io.oxia.okk.proto.v1.OperationDeleteRange
-	(*Operation)(nil),               // 10: io.oxia.okk.proto.v1.Operation
-	(*Precondition)(nil),            // 11: io.oxia.okk.proto.v1.Precondition
-	(*Notification)(nil),            // 12: io.oxia.okk.proto.v1.Notification
-	(*Record)(nil),                  // 13: io.oxia.okk.proto.v1.Record
-	(*Assertion)(nil),               // 14: io.oxia.okk.proto.v1.Assertion
-	(*ExecuteCommand)(nil),          // 15: io.oxia.okk.proto.v1.ExecuteCommand
-	(*ExecuteResponse)(nil),         // 16: io.oxia.okk.proto.v1.ExecuteResponse
+	(KeyComparisonType)(0),           // 0: io.oxia.okk.proto.v1.KeyComparisonType
+	(SubscriptionAction)(0),          // 1: io.oxia.okk.proto.v1.SubscriptionAction
+	(NotificationType)(0),            // 2: io.oxia.okk.proto.v1.NotificationType
+	(ExpectedError)(0),               // 3: io.oxia.okk.proto.v1.ExpectedError
+	(AssertionMode)(0),               // 4: io.oxia.okk.proto.v1.AssertionMode
+	(Status)(0),                      // 5: io.oxia.okk.proto.v1.Status
+	(*OperationSessionRestart)(nil),  // 6: io.oxia.okk.proto.v1.OperationSessionRestart
+	(*SecondaryIndex)(nil),           // 7: io.oxia.okk.proto.v1.SecondaryIndex
+	(*OperationPut)(nil),             // 8: io.oxia.okk.proto.v1.OperationPut
+	(*OperationGet)(nil),             // 9: io.oxia.okk.proto.v1.OperationGet
+	(*OperationList)(nil),            // 10: io.oxia.okk.proto.v1.OperationList
+	(*OperationScan)(nil),            // 11: io.oxia.okk.proto.v1.OperationScan
+	(*OperationDelete)(nil),          // 12: io.oxia.okk.proto.v1.OperationDelete
+	(*OperationDeleteRange)(nil),     // 13: io.oxia.okk.proto.v1.OperationDeleteRange
+	(*OperationSequenceUpdates)(nil), // 14: io.oxia.okk.proto.v1.OperationSequenceUpdates
+	(*Operation)(nil),                // 15: io.oxia.okk.proto.v1.Operation
+	(*Precondition)(nil),             // 16: io.oxia.okk.proto.v1.Precondition
+	(*Notification)(nil),             // 17: io.oxia.okk.proto.v1.Notification
+	(*Record)(nil),                   // 18: io.oxia.okk.proto.v1.Record
+	(*Eventually)(nil),               // 19: io.oxia.okk.proto.v1.Eventually
+	(*Assertion)(nil),                // 20: io.oxia.okk.proto.v1.Assertion
+	(*ExecuteCommand)(nil),           // 21: io.oxia.okk.proto.v1.ExecuteCommand
+	(*AssertionFailureDetail)(nil),   // 22: io.oxia.okk.proto.v1.AssertionFailureDetail
+	(*ExecuteResponse)(nil),          // 23: io.oxia.okk.proto.v1.ExecuteResponse
+	(*DescribeRequest)(nil),          // 24: io.oxia.okk.proto.v1.DescribeRequest
+	(*DescribeResponse)(nil),         // 25: io.oxia.okk.proto.v1.DescribeResponse
+	(*RegisterRequest)(nil),          // 26: io.oxia.okk.proto.v1.RegisterRequest
+	(*RegisterResponse)(nil),         // 27: io.oxia.okk.proto.v1.RegisterResponse
+	(*HeartbeatRequest)(nil),         // 28: io.oxia.okk.proto.v1.HeartbeatRequest
+	(*HeartbeatResponse)(nil),        // 29: io.oxia.okk.proto.v1.HeartbeatResponse
+	(*DeregisterRequest)(nil),        // 30: io.oxia.okk.proto.v1.DeregisterRequest
+	(*DeregisterResponse)(nil),       // 31: io.oxia.okk.proto.v1.DeregisterResponse
+	(*FailurePolicy)(nil),            // 32: io.oxia.okk.proto.v1.FailurePolicy
+	(*WorkerAuth)(nil),               // 33: io.oxia.okk.proto.v1.WorkerAuth
+	(*TestCase)(nil),                 // 34: io.oxia.okk.proto.v1.TestCase
+	(*FailedRecord)(nil),             // 35: io.oxia.okk.proto.v1.FailedRecord
+	(*TestCaseFailure)(nil),          // 36: io.oxia.okk.proto.v1.TestCaseFailure
+	(*TestCaseStatus)(nil),           // 37: io.oxia.okk.proto.v1.TestCaseStatus
+	(*CreateTestCaseRequest)(nil),    // 38: io.oxia.okk.proto.v1.CreateTestCaseRequest
+	(*ListTestCasesRequest)(nil),     // 39: io.oxia.okk.proto.v1.ListTestCasesRequest
+	(*ListTestCasesResponse)(nil),    // 40: io.oxia.okk.proto.v1.ListTestCasesResponse
+	(*GetTestCaseRequest)(nil),       // 41: io.oxia.okk.proto.v1.GetTestCaseRequest
+	(*DeleteTestCaseRequest)(nil),    // 42: io.oxia.okk.proto.v1.DeleteTestCaseRequest
+	(*DeleteTestCaseResponse)(nil),   // 43: io.oxia.okk.proto.v1.DeleteTestCaseResponse
+	(*WatchTestCaseRequest)(nil),     // 44: io.oxia.okk.proto.v1.WatchTestCaseRequest
+	nil,                              // 45: io.oxia.okk.proto.v1.RegisterRequest.LabelsEntry
+	nil,                              // 46: io.oxia.okk.proto.v1.TestCase.WorkerSelectorEntry
+	nil,                              // 47: io.oxia.okk.proto.v1.TestCase.OpTimeoutsEntry
+	nil,                              // 48: io.oxia.okk.proto.v1.TestCase.PropertiesEntry
 }
 var file_okk_proto_depIdxs = []int32{
-	0,  // 0: io.oxia.okk.proto.v1.OperationGet.comparison_type:type_name -> io.oxia.okk.proto.v1.KeyComparisonType
-	14, // 1: io.oxia.okk.proto.v1.Operation.assertion:type_name -> io.oxia.okk.proto.v1.Assertion
-	11, // 2: io.oxia.okk.proto.v1.Operation.precondition:type_name -> io.oxia.okk.proto.v1.Precondition
-	4,  // 3: io.oxia.okk.proto.v1.Operation.put:type_name -> io.oxia.okk.proto.v1.OperationPut
-	8,  // 4: io.oxia.okk.proto.v1.Operation.delete:type_name -> io.oxia.okk.proto.v1.OperationDelete
-	5,  // 5: io.oxia.okk.proto.v1.Operation.get:type_name -> io.oxia.okk.proto.v1.OperationGet
-	6,  // 6: io.oxia.okk.proto.v1.Operation.list:type_name -> io.oxia.okk.proto.v1.OperationList
-	7,  // 7: io.oxia.okk.proto.v1.Operation.scan:type_name -> io.oxia.okk.proto.v1.OperationScan
-	3,  // 8: io.oxia.okk.proto.v1.Operation.session_restart:type_name -> io.oxia.okk.proto.v1.OperationSessionRestart
-	9,  // 9: io.oxia.okk.proto.v1.Operation.delete_range:type_name -> io.oxia.okk.proto.v1.OperationDeleteRange
-	1,  // 10: io.oxia.okk.proto.v1.Notification.type:type_name -> io.oxia.okk.proto.v1.NotificationType
-	13, // 11: io.oxia.okk.proto.v1.Assertion.records:type_name -> io.oxia.okk.proto.v1.Record
-	12, // 12: io.oxia.okk.proto.v1.Assertion.notification:type_name -> io.oxia.okk.proto.v1.Notification
-	10, // 13: io.oxia.okk.proto.v1.ExecuteCommand.operation:type_name -> io.oxia.okk.proto.v1.Operation
-	2,  // 14: io.oxia.okk.proto.v1.ExecuteResponse.status:type_name -> io.oxia.okk.proto.v1.Status
-	15, // 15: io.oxia.okk.proto.v1.Okk.Execute:input_type -> io.oxia.okk.proto.v1.ExecuteCommand
-	16, // 16: io.oxia.okk.proto.v1.Okk.Execute:output_type -> io.oxia.okk.proto.v1.ExecuteResponse
-	16, // [16:17] is the sub-list for method output_type
-	15, // [15:16] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	7,  // 0: io.oxia.okk.proto.v1.OperationPut.secondary_indexes:type_name -> io.oxia.okk.proto.v1.SecondaryIndex
+	0,  // 1: io.oxia.okk.proto.v1.OperationGet.comparison_type:type_name -> io.oxia.okk.proto.v1.KeyComparisonType
+	1,  // 2: io.oxia.okk.proto.v1.OperationSequenceUpdates.action:type_name -> io.oxia.okk.proto.v1.SubscriptionAction
+	20, // 3: io.oxia.okk.proto.v1.Operation.assertion:type_name -> io.oxia.okk.proto.v1.Assertion
+	16, // 4: io.oxia.okk.proto.v1.Operation.precondition:type_name -> io.oxia.okk.proto.v1.Precondition
+	8,  // 5: io.oxia.okk.proto.v1.Operation.put:type_name -> io.oxia.okk.proto.v1.OperationPut
+	12, // 6: io.oxia.okk.proto.v1.Operation.delete:type_name -> io.oxia.okk.proto.v1.OperationDelete
+	9,  // 7: io.oxia.okk.proto.v1.Operation.get:type_name -> io.oxia.okk.proto.v1.OperationGet
+	10, // 8: io.oxia.okk.proto.v1.Operation.list:type_name -> io.oxia.okk.proto.v1.OperationList
+	11, // 9: io.oxia.okk.proto.v1.Operation.scan:type_name -> io.oxia.okk.proto.v1.OperationScan
+	6,  // 10: io.oxia.okk.proto.v1.Operation.session_restart:type_name -> io.oxia.okk.proto.v1.OperationSessionRestart
+	13, // 11: io.oxia.okk.proto.v1.Operation.delete_range:type_name -> io.oxia.okk.proto.v1.OperationDeleteRange
+	14, // 12: io.oxia.okk.proto.v1.Operation.sequence_updates:type_name -> io.oxia.okk.proto.v1.OperationSequenceUpdates
+	2,  // 13: io.oxia.okk.proto.v1.Notification.type:type_name -> io.oxia.okk.proto.v1.NotificationType
+	18, // 14: io.oxia.okk.proto.v1.Assertion.records:type_name -> io.oxia.okk.proto.v1.Record
+	17, // 15: io.oxia.okk.proto.v1.Assertion.notification:type_name -> io.oxia.okk.proto.v1.Notification
+	19, // 16: io.oxia.okk.proto.v1.Assertion.eventually:type_name -> io.oxia.okk.proto.v1.Eventually
+	3,  // 17: io.oxia.okk.proto.v1.Assertion.expected_error:type_name -> io.oxia.okk.proto.v1.ExpectedError
+	15, // 18: io.oxia.okk.proto.v1.ExecuteCommand.operation:type_name -> io.oxia.okk.proto.v1.Operation
+	4,  // 19: io.oxia.okk.proto.v1.ExecuteCommand.assertion_mode:type_name -> io.oxia.okk.proto.v1.AssertionMode
+	18, // 20: io.oxia.okk.proto.v1.AssertionFailureDetail.expected_records:type_name -> io.oxia.okk.proto.v1.Record
+	18, // 21: io.oxia.okk.proto.v1.AssertionFailureDetail.actual_records:type_name -> io.oxia.okk.proto.v1.Record
+	17, // 22: io.oxia.okk.proto.v1.AssertionFailureDetail.expected_notification:type_name -> io.oxia.okk.proto.v1.Notification
+	17, // 23: io.oxia.okk.proto.v1.AssertionFailureDetail.actual_notification:type_name -> io.oxia.okk.proto.v1.Notification
+	3,  // 24: io.oxia.okk.proto.v1.AssertionFailureDetail.expected_error:type_name -> io.oxia.okk.proto.v1.ExpectedError
+	3,  // 25: io.oxia.okk.proto.v1.AssertionFailureDetail.actual_error:type_name -> io.oxia.okk.proto.v1.ExpectedError
+	5,  // 26: io.oxia.okk.proto.v1.ExecuteResponse.status:type_name -> io.oxia.okk.proto.v1.Status
+	18, // 27: io.oxia.okk.proto.v1.ExecuteResponse.records:type_name -> io.oxia.okk.proto.v1.Record
+	17, // 28: io.oxia.okk.proto.v1.ExecuteResponse.notification:type_name -> io.oxia.okk.proto.v1.Notification
+	22, // 29: io.oxia.okk.proto.v1.ExecuteResponse.failure_detail:type_name -> io.oxia.okk.proto.v1.AssertionFailureDetail
+	3,  // 30: io.oxia.okk.proto.v1.ExecuteResponse.error:type_name -> io.oxia.okk.proto.v1.ExpectedError
+	4,  // 31: io.oxia.okk.proto.v1.DescribeResponse.assertion_modes:type_name -> io.oxia.okk.proto.v1.AssertionMode
+	45, // 32: io.oxia.okk.proto.v1.RegisterRequest.labels:type_name -> io.oxia.okk.proto.v1.RegisterRequest.LabelsEntry
+	46, // 33: io.oxia.okk.proto.v1.TestCase.worker_selector:type_name -> io.oxia.okk.proto.v1.TestCase.WorkerSelectorEntry
+	47, // 34: io.oxia.okk.proto.v1.TestCase.op_timeouts:type_name -> io.oxia.okk.proto.v1.TestCase.OpTimeoutsEntry
+	32, // 35: io.oxia.okk.proto.v1.TestCase.failure_policy:type_name -> io.oxia.okk.proto.v1.FailurePolicy
+	33, // 36: io.oxia.okk.proto.v1.TestCase.worker_auth:type_name -> io.oxia.okk.proto.v1.WorkerAuth
+	48, // 37: io.oxia.okk.proto.v1.TestCase.properties:type_name -> io.oxia.okk.proto.v1.TestCase.PropertiesEntry
+	35, // 38: io.oxia.okk.proto.v1.TestCaseFailure.expected_records:type_name -> io.oxia.okk.proto.v1.FailedRecord
+	35, // 39: io.oxia.okk.proto.v1.TestCaseFailure.actual_records:type_name -> io.oxia.okk.proto.v1.FailedRecord
+	36, // 40: io.oxia.okk.proto.v1.TestCaseStatus.failures:type_name -> io.oxia.okk.proto.v1.TestCaseFailure
+	34, // 41: io.oxia.okk.proto.v1.CreateTestCaseRequest.test_case:type_name -> io.oxia.okk.proto.v1.TestCase
+	37, // 42: io.oxia.okk.proto.v1.ListTestCasesResponse.test_cases:type_name -> io.oxia.okk.proto.v1.TestCaseStatus
+	21, // 43: io.oxia.okk.proto.v1.Okk.Execute:input_type -> io.oxia.okk.proto.v1.ExecuteCommand
+	24, // 44: io.oxia.okk.proto.v1.Okk.Describe:input_type -> io.oxia.okk.proto.v1.DescribeRequest
+	26, // 45: io.oxia.okk.proto.v1.OkkRegistry.Register:input_type -> io.oxia.okk.proto.v1.RegisterRequest
+	28, // 46: io.oxia.okk.proto.v1.OkkRegistry.Heartbeat:input_type -> io.oxia.okk.proto.v1.HeartbeatRequest
+	30, // 47: io.oxia.okk.proto.v1.OkkRegistry.Deregister:input_type -> io.oxia.okk.proto.v1.DeregisterRequest
+	38, // 48: io.oxia.okk.proto.v1.OkkAdmin.CreateTestCase:input_type -> io.oxia.okk.proto.v1.CreateTestCaseRequest
+	39, // 49: io.oxia.okk.proto.v1.OkkAdmin.ListTestCases:input_type -> io.oxia.okk.proto.v1.ListTestCasesRequest
+	41, // 50: io.oxia.okk.proto.v1.OkkAdmin.GetTestCase:input_type -> io.oxia.okk.proto.v1.GetTestCaseRequest
+	42, // 51: io.oxia.okk.proto.v1.OkkAdmin.DeleteTestCase:input_type -> io.oxia.okk.proto.v1.DeleteTestCaseRequest
+	44, // 52: io.oxia.okk.proto.v1.OkkAdmin.WatchTestCase:input_type -> io.oxia.okk.proto.v1.WatchTestCaseRequest
+	23, // 53: io.oxia.okk.proto.v1.Okk.Execute:output_type -> io.oxia.okk.proto.v1.ExecuteResponse
+	25, // 54: io.oxia.okk.proto.v1.Okk.Describe:output_type -> io.oxia.okk.proto.v1.DescribeResponse
+	27, // 55: io.oxia.okk.proto.v1.OkkRegistry.Register:output_type -> io.oxia.okk.proto.v1.RegisterResponse
+	29, // 56: io.oxia.okk.proto.v1.OkkRegistry.Heartbeat:output_type -> io.oxia.okk.proto.v1.HeartbeatResponse
+	31, // 57: io.oxia.okk.proto.v1.OkkRegistry.Deregister:output_type -> io.oxia.okk.proto.v1.DeregisterResponse
+	37, // 58: io.oxia.okk.proto.v1.OkkAdmin.CreateTestCase:output_type -> io.oxia.okk.proto.v1.TestCaseStatus
+	40, // 59: io.oxia.okk.proto.v1.OkkAdmin.ListTestCases:output_type -> io.oxia.okk.proto.v1.ListTestCasesResponse
+	37, // 60: io.oxia.okk.proto.v1.OkkAdmin.GetTestCase:output_type -> io.oxia.okk.proto.v1.TestCaseStatus
+	43, // 61: io.oxia.okk.proto.v1.OkkAdmin.DeleteTestCase:output_type -> io.oxia.okk.proto.v1.DeleteTestCaseResponse
+	37, // 62: io.oxia.okk.proto.v1.OkkAdmin.WatchTestCase:output_type -> io.oxia.okk.proto.v1.TestCaseStatus
+	53, // [53:63] is the sub-list for method output_type
+	43, // [43:53] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_okk_proto_init() }
@@ -1292,8 +3655,10 @@ func file_okk_proto_init() {
 	if File_okk_proto != nil {
 		return
 	}
-	file_okk_proto_msgTypes[1].OneofWrappers = []any{}
-	file_okk_proto_msgTypes[7].OneofWrappers = []any{
+	file_okk_proto_msgTypes[2].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[3].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[4].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[9].OneofWrappers = []any{
 		(*Operation_Put)(nil),
 		(*Operation_Delete)(nil),
 		(*Operation_Get)(nil),
@@ -1301,20 +3666,27 @@ func file_okk_proto_init() {
 		(*Operation_Scan)(nil),
 		(*Operation_SessionRestart)(nil),
 		(*Operation_DeleteRange)(nil),
+		(*Operation_SequenceUpdates)(nil),
 	}
-	file_okk_proto_msgTypes[8].OneofWrappers = []any{}
-	file_okk_proto_msgTypes[9].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[10].OneofWrappers = []any{}
 	file_okk_proto_msgTypes[11].OneofWrappers = []any{}
-	file_okk_proto_msgTypes[13].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[12].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[14].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[16].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[17].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[28].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[29].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[30].OneofWrappers = []any{}
+	file_okk_proto_msgTypes[31].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_okk_proto_rawDesc), len(file_okk_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   14,
+			NumEnums:      6,
+			NumMessages:   43,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_okk_proto_goTypes,
 		DependencyIndexes: file_okk_proto_depIdxs,
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Okk_Execute_FullMethodName = "/io.oxia.okk.proto.v1.Okk/Execute"
+	Okk_Execute_FullMethodName  = "/io.oxia.okk.proto.v1.Okk/Execute"
+	Okk_Describe_FullMethodName = "/io.oxia.okk.proto.v1.Okk/Describe"
 )
 
 // OkkClient is the client API for Okk service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OkkClient interface {
 	Execute(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecuteCommand, ExecuteResponse], error)
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
 }
 
 type okkClient struct {
@@ -50,11 +52,22 @@ func (c *okkClient) Execute(ctx context.Context, opts ...grpc.CallOption) (grpc.
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Okk_ExecuteClient = grpc.BidiStreamingClient[ExecuteCommand, ExecuteResponse]
 
+func (c *okkClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeResponse)
+	err := c.cc.Invoke(ctx, Okk_Describe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OkkServer is the server API for Okk service.
 // All implementations must embed UnimplementedOkkServer
 // for forward compatibility.
 type OkkServer interface {
 	Execute(grpc.BidiStreamingServer[ExecuteCommand, ExecuteResponse]) error
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
 	mustEmbedUnimplementedOkkServer()
 }
 
@@ -68,6 +81,9 @@ type UnimplementedOkkServer struct{}
 func (UnimplementedOkkServer) Execute(grpc.BidiStreamingServer[ExecuteCommand, ExecuteResponse]) error {
 	return status.Error(codes.Unimplemented, "method Execute not implemented")
 }
+func (UnimplementedOkkServer) Describe(context.Context, *DescribeRequest) (*DescribeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Describe not implemented")
+}
 func (UnimplementedOkkServer) mustEmbedUnimplementedOkkServer() {}
 func (UnimplementedOkkServer) testEmbeddedByValue()             {}
 
@@ -96,13 +112,36 @@ func _Okk_Execute_Handler(srv interface{}, stream grpc.ServerStream) error {
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Okk_ExecuteServer = grpc.BidiStreamingServer[ExecuteCommand, ExecuteResponse]
 
+func _Okk_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OkkServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Okk_Describe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OkkServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Okk_ServiceDesc is the grpc.ServiceDesc for Okk service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Okk_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "io.oxia.okk.proto.v1.Okk",
 	HandlerType: (*OkkServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler:    _Okk_Describe_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Execute",
@@ -113,3 +152,457 @@ var Okk_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "okk.proto",
 }
+
+const (
+	OkkRegistry_Register_FullMethodName   = "/io.oxia.okk.proto.v1.OkkRegistry/Register"
+	OkkRegistry_Heartbeat_FullMethodName  = "/io.oxia.okk.proto.v1.OkkRegistry/Heartbeat"
+	OkkRegistry_Deregister_FullMethodName = "/io.oxia.okk.proto.v1.OkkRegistry/Deregister"
+)
+
+// OkkRegistryClient is the client API for OkkRegistry service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OkkRegistry is served by the coordinator, so that workers can join and
+// leave without editing the testcases. A heartbeat of an unknown worker
+// fails with NOT_FOUND, and the worker must register again.
+type OkkRegistryClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*DeregisterResponse, error)
+}
+
+type okkRegistryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOkkRegistryClient(cc grpc.ClientConnInterface) OkkRegistryClient {
+	return &okkRegistryClient{cc}
+}
+
+func (c *okkRegistryClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, OkkRegistry_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *okkRegistryClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, OkkRegistry_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *okkRegistryClient) Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*DeregisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeregisterResponse)
+	err := c.cc.Invoke(ctx, OkkRegistry_Deregister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OkkRegistryServer is the server API for OkkRegistry service.
+// All implementations must embed UnimplementedOkkRegistryServer
+// for forward compatibility.
+//
+// OkkRegistry is served by the coordinator, so that workers can join and
+// leave without editing the testcases. A heartbeat of an unknown worker
+// fails with NOT_FOUND, and the worker must register again.
+type OkkRegistryServer interface {
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	Deregister(context.Context, *DeregisterRequest) (*DeregisterResponse, error)
+	mustEmbedUnimplementedOkkRegistryServer()
+}
+
+// UnimplementedOkkRegistryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOkkRegistryServer struct{}
+
+func (UnimplementedOkkRegistryServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedOkkRegistryServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedOkkRegistryServer) Deregister(context.Context, *DeregisterRequest) (*DeregisterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Deregister not implemented")
+}
+func (UnimplementedOkkRegistryServer) mustEmbedUnimplementedOkkRegistryServer() {}
+func (UnimplementedOkkRegistryServer) testEmbeddedByValue()                     {}
+
+// UnsafeOkkRegistryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OkkRegistryServer will
+// result in compilation errors.
+type UnsafeOkkRegistryServer interface {
+	mustEmbedUnimplementedOkkRegistryServer()
+}
+
+func RegisterOkkRegistryServer(s grpc.ServiceRegistrar, srv OkkRegistryServer) {
+	// If the following call panics, it indicates UnimplementedOkkRegistryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OkkRegistry_ServiceDesc, srv)
+}
+
+func _OkkRegistry_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OkkRegistryServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OkkRegistry_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OkkRegistryServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OkkRegistry_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OkkRegistryServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OkkRegistry_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OkkRegistryServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OkkRegistry_Deregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OkkRegistryServer).Deregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OkkRegistry_Deregister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OkkRegistryServer).Deregister(ctx, req.(*DeregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OkkRegistry_ServiceDesc is the grpc.ServiceDesc for OkkRegistry service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OkkRegistry_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "io.oxia.okk.proto.v1.OkkRegistry",
+	HandlerType: (*OkkRegistryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _OkkRegistry_Register_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _OkkRegistry_Heartbeat_Handler,
+		},
+		{
+			MethodName: "Deregister",
+			Handler:    _OkkRegistry_Deregister_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "okk.proto",
+}
+
+const (
+	OkkAdmin_CreateTestCase_FullMethodName = "/io.oxia.okk.proto.v1.OkkAdmin/CreateTestCase"
+	OkkAdmin_ListTestCases_FullMethodName  = "/io.oxia.okk.proto.v1.OkkAdmin/ListTestCases"
+	OkkAdmin_GetTestCase_FullMethodName    = "/io.oxia.okk.proto.v1.OkkAdmin/GetTestCase"
+	OkkAdmin_DeleteTestCase_FullMethodName = "/io.oxia.okk.proto.v1.OkkAdmin/DeleteTestCase"
+	OkkAdmin_WatchTestCase_FullMethodName  = "/io.oxia.okk.proto.v1.OkkAdmin/WatchTestCase"
+)
+
+// OkkAdminClient is the client API for OkkAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OkkAdmin is served by the coordinator next to OkkRegistry, and mirrors the
+// HTTP testcase endpoints with the same roles: reading needs the reader role
+// and creating or deleting the operator role. WatchTestCase sends the status
+// whenever it changes, and ends once the testcase is no longer running.
+type OkkAdminClient interface {
+	CreateTestCase(ctx context.Context, in *CreateTestCaseRequest, opts ...grpc.CallOption) (*TestCaseStatus, error)
+	ListTestCases(ctx context.Context, in *ListTestCasesRequest, opts ...grpc.CallOption) (*ListTestCasesResponse, error)
+	GetTestCase(ctx context.Context, in *GetTestCaseRequest, opts ...grpc.CallOption) (*TestCaseStatus, error)
+	DeleteTestCase(ctx context.Context, in *DeleteTestCaseRequest, opts ...grpc.CallOption) (*DeleteTestCaseResponse, error)
+	WatchTestCase(ctx context.Context, in *WatchTestCaseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TestCaseStatus], error)
+}
+
+type okkAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOkkAdminClient(cc grpc.ClientConnInterface) OkkAdminClient {
+	return &okkAdminClient{cc}
+}
+
+func (c *okkAdminClient) CreateTestCase(ctx context.Context, in *CreateTestCaseRequest, opts ...grpc.CallOption) (*TestCaseStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestCaseStatus)
+	err := c.cc.Invoke(ctx, OkkAdmin_CreateTestCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *okkAdminClient) ListTestCases(ctx context.Context, in *ListTestCasesRequest, opts ...grpc.CallOption) (*ListTestCasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTestCasesResponse)
+	err := c.cc.Invoke(ctx, OkkAdmin_ListTestCases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *okkAdminClient) GetTestCase(ctx context.Context, in *GetTestCaseRequest, opts ...grpc.CallOption) (*TestCaseStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestCaseStatus)
+	err := c.cc.Invoke(ctx, OkkAdmin_GetTestCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *okkAdminClient) DeleteTestCase(ctx context.Context, in *DeleteTestCaseRequest, opts ...grpc.CallOption) (*DeleteTestCaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTestCaseResponse)
+	err := c.cc.Invoke(ctx, OkkAdmin_DeleteTestCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *okkAdminClient) WatchTestCase(ctx context.Context, in *WatchTestCaseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TestCaseStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OkkAdmin_ServiceDesc.Streams[0], OkkAdmin_WatchTestCase_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTestCaseRequest, TestCaseStatus]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OkkAdmin_WatchTestCaseClient = grpc.ServerStreamingClient[TestCaseStatus]
+
+// OkkAdminServer is the server API for OkkAdmin service.
+// All implementations must embed UnimplementedOkkAdminServer
+// for forward compatibility.
+//
+// OkkAdmin is served by the coordinator next to OkkRegistry, and mirrors the
+// HTTP testcase endpoints with the same roles: reading needs the reader role
+// and creating or deleting the operator role. WatchTestCase sends the status
+// whenever it changes, and ends once the testcase is no longer running.
+type OkkAdminServer interface {
+	CreateTestCase(context.Context, *CreateTestCaseRequest) (*TestCaseStatus, error)
+	ListTestCases(context.Context, *ListTestCasesRequest) (*ListTestCasesResponse, error)
+	GetTestCase(context.Context, *GetTestCaseRequest) (*TestCaseStatus, error)
+	DeleteTestCase(context.Context, *DeleteTestCaseRequest) (*DeleteTestCaseResponse, error)
+	WatchTestCase(*WatchTestCaseRequest, grpc.ServerStreamingServer[TestCaseStatus]) error
+	mustEmbedUnimplementedOkkAdminServer()
+}
+
+// UnimplementedOkkAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOkkAdminServer struct{}
+
+func (UnimplementedOkkAdminServer) CreateTestCase(context.Context, *CreateTestCaseRequest) (*TestCaseStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTestCase not implemented")
+}
+func (UnimplementedOkkAdminServer) ListTestCases(context.Context, *ListTestCasesRequest) (*ListTestCasesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTestCases not implemented")
+}
+func (UnimplementedOkkAdminServer) GetTestCase(context.Context, *GetTestCaseRequest) (*TestCaseStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTestCase not implemented")
+}
+func (UnimplementedOkkAdminServer) DeleteTestCase(context.Context, *DeleteTestCaseRequest) (*DeleteTestCaseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTestCase not implemented")
+}
+func (UnimplementedOkkAdminServer) WatchTestCase(*WatchTestCaseRequest, grpc.ServerStreamingServer[TestCaseStatus]) error {
+	return status.Error(codes.Unimplemented, "method WatchTestCase not implemented")
+}
+func (UnimplementedOkkAdminServer) mustEmbedUnimplementedOkkAdminServer() {}
+func (UnimplementedOkkAdminServer) testEmbeddedByValue()                  {}
+
+// UnsafeOkkAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OkkAdminServer will
+// result in compilation errors.
+type UnsafeOkkAdminServer interface {
+	mustEmbedUnimplementedOkkAdminServer()
+}
+
+func RegisterOkkAdminServer(s grpc.ServiceRegistrar, srv OkkAdminServer) {
+	// If the following call panics, it indicates UnimplementedOkkAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OkkAdmin_ServiceDesc, srv)
+}
+
+func _OkkAdmin_CreateTestCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTestCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OkkAdminServer).CreateTestCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OkkAdmin_CreateTestCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OkkAdminServer).CreateTestCase(ctx, req.(*CreateTestCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OkkAdmin_ListTestCases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTestCasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OkkAdminServer).ListTestCases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OkkAdmin_ListTestCases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OkkAdminServer).ListTestCases(ctx, req.(*ListTestCasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OkkAdmin_GetTestCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTestCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OkkAdminServer).GetTestCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OkkAdmin_GetTestCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OkkAdminServer).GetTestCase(ctx, req.(*GetTestCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OkkAdmin_DeleteTestCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTestCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OkkAdminServer).DeleteTestCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OkkAdmin_DeleteTestCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OkkAdminServer).DeleteTestCase(ctx, req.(*DeleteTestCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OkkAdmin_WatchTestCase_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTestCaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OkkAdminServer).WatchTestCase(m, &grpc.GenericServerStream[WatchTestCaseRequest, TestCaseStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OkkAdmin_WatchTestCaseServer = grpc.ServerStreamingServer[TestCaseStatus]
+
+// OkkAdmin_ServiceDesc is the grpc.ServiceDesc for OkkAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OkkAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "io.oxia.okk.proto.v1.OkkAdmin",
+	HandlerType: (*OkkAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTestCase",
+			Handler:    _OkkAdmin_CreateTestCase_Handler,
+		},
+		{
+			MethodName: "ListTestCases",
+			Handler:    _OkkAdmin_ListTestCases_Handler,
+		},
+		{
+			MethodName: "GetTestCase",
+			Handler:    _OkkAdmin_GetTestCase_Handler,
+		},
+		{
+			MethodName: "DeleteTestCase",
+			Handler:    _OkkAdmin_DeleteTestCase_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTestCase",
+			Handler:       _OkkAdmin_WatchTestCase_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "okk.proto",
+}
@@ -0,0 +1,192 @@
+package task
+
+import (
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strings"
+
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+)
+
+// maxDiffEntries bounds how many keys of each kind AssertionDiff.String lists.
+const maxDiffEntries = 10
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// AssertionDiff is the difference between what an assertion expects and
+// what the worker observed.
+type AssertionDiff struct {
+	MissingKeys     []string `json:"missing_keys,omitempty"`
+	ExtraKeys       []string `json:"extra_keys,omitempty"`
+	ValueMismatches []string `json:"value_mismatches,omitempty"`
+	// Mismatches holds the differences that are not about a single key,
+	// such as notifications, version conflicts or ordering.
+	Mismatches []string `json:"mismatches,omitempty"`
+}
+
+func (d *AssertionDiff) empty() bool {
+	return len(d.MissingKeys) == 0 && len(d.ExtraKeys) == 0 &&
+		len(d.ValueMismatches) == 0 && len(d.Mismatches) == 0
+}
+
+func (d *AssertionDiff) String() string {
+	parts := make([]string, 0, 4)
+	if len(d.MissingKeys) > 0 {
+		parts = append(parts, "missing keys "+truncate(d.MissingKeys))
+	}
+	if len(d.ExtraKeys) > 0 {
+		parts = append(parts, "extra keys "+truncate(d.ExtraKeys))
+	}
+	if len(d.ValueMismatches) > 0 {
+		parts = append(parts, "mismatched values of keys "+truncate(d.ValueMismatches))
+	}
+	parts = append(parts, d.Mismatches...)
+	return strings.Join(parts, "; ")
+}
+
+func truncate(keys []string) string {
+	if len(keys) <= maxDiffEntries {
+		return fmt.Sprintf("%q", keys)
+	}
+	return fmt.Sprintf("%q and %d more", keys[:maxDiffEntries], len(keys)-maxDiffEntries)
+}
+
+// EvaluateAssertion compares the observations reported by a worker in the
+// COORDINATOR assertion mode against the assertion of the operation. It
+// returns nil when the assertion holds.
+func EvaluateAssertion(operation *proto.Operation, response *proto.ExecuteResponse) *AssertionDiff {
+	assertion := operation.Assertion
+	if assertion == nil {
+		return nil
+	}
+	if assertion.ExpectedError != nil {
+		if expected, actual := assertion.GetExpectedError(), response.GetError(); expected != actual {
+			return &AssertionDiff{
+				Mismatches: []string{fmt.Sprintf("expected error %s, actual %s", expected, actual)},
+			}
+		}
+		return nil
+	}
+	diff := &AssertionDiff{}
+	switch operation.Operation.(type) {
+	case *proto.Operation_Put:
+		switch {
+		case assertion.GetExpectVersionConflict() && !response.GetVersionConflict():
+			diff.Mismatches = append(diff.Mismatches, "expected version conflict but put succeeded")
+		case !assertion.GetExpectVersionConflict() && response.GetVersionConflict():
+			diff.Mismatches = append(diff.Mismatches, "unexpected version conflict")
+		}
+		if len(assertion.Records) > 0 && !response.GetVersionConflict() {
+			// only the key is known up front, e.g. for sequence keys
+			compareRecords(diff, assertion.Records[:1], response.Records, false)
+		}
+	case *proto.Operation_Get:
+		if assertion.EmptyRecords != nil || len(assertion.Records) > 0 {
+			compareRecords(diff, expectedRecords(assertion), response.Records, true)
+		}
+	case *proto.Operation_List:
+		compareRecords(diff, expectedRecords(assertion), response.Records, false)
+	case *proto.Operation_Scan:
+		compareRecords(diff, expectedRecords(assertion), response.Records, true)
+	case *proto.Operation_SequenceUpdates:
+		compareSequenceUpdates(diff, assertion.SequenceUpdates, response.SequenceUpdates)
+	}
+	if assertion.Notification != nil {
+		compareNotification(diff, assertion.Notification, response.Notification)
+	}
+	if diff.empty() {
+		return nil
+	}
+	return diff
+}
+
+func expectedRecords(assertion *proto.Assertion) []*proto.Record {
+	if assertion.GetEmptyRecords() || assertion.GetEventuallyEmpty() {
+		return nil
+	}
+	return assertion.Records
+}
+
+func compareRecords(diff *AssertionDiff, expected []*proto.Record, actual []*proto.Record, withValues bool) {
+	actualByKey := make(map[string]*proto.Record, len(actual))
+	for _, record := range actual {
+		actualByKey[record.Key] = record
+	}
+	expectedKeys := make(map[string]struct{}, len(expected))
+	for _, record := range expected {
+		expectedKeys[record.Key] = struct{}{}
+		actualRecord, found := actualByKey[record.Key]
+		if !found {
+			diff.MissingKeys = append(diff.MissingKeys, record.Key)
+			continue
+		}
+		if withValues && !matchesValue(record, actualRecord.Value) {
+			diff.ValueMismatches = append(diff.ValueMismatches, record.Key)
+		}
+	}
+	for _, record := range actual {
+		if _, found := expectedKeys[record.Key]; !found {
+			diff.ExtraKeys = append(diff.ExtraKeys, record.Key)
+		}
+	}
+	if len(diff.MissingKeys) == 0 && len(diff.ExtraKeys) == 0 &&
+		!slices.EqualFunc(expected, actual, func(a, b *proto.Record) bool { return a.Key == b.Key }) {
+		diff.Mismatches = append(diff.Mismatches, "records out of order")
+	}
+}
+
+// matchesValue compares the actual value with the expected record, by
+// checksum and length when the record carries them instead of the value.
+func matchesValue(expected *proto.Record, actual []byte) bool {
+	if expected.ValueChecksum != nil {
+		return uint64(len(actual)) == expected.GetValueLength() &&
+			crc32.Checksum(actual, castagnoli) == expected.GetValueChecksum()
+	}
+	return bytes.Equal(expected.Value, actual)
+}
+
+func compareNotification(diff *AssertionDiff, expected *proto.Notification, actual *proto.Notification) {
+	if actual == nil {
+		diff.Mismatches = append(diff.Mismatches, fmt.Sprintf("missing %s notification", expected.Type))
+		return
+	}
+	if expected.Type != actual.Type ||
+		expected.GetKey() != actual.GetKey() ||
+		expected.GetKeyStart() != actual.GetKeyStart() ||
+		expected.GetKeyEnd() != actual.GetKeyEnd() {
+		diff.Mismatches = append(diff.Mismatches, fmt.Sprintf("mismatched notification, expected %s, actual %s",
+			formatNotification(expected), formatNotification(actual)))
+	}
+}
+
+func formatNotification(notification *proto.Notification) string {
+	if notification.Type == proto.NotificationType_KEY_RANGE_DELETED {
+		return fmt.Sprintf("%s[%q, %q)", notification.Type, notification.GetKeyStart(), notification.GetKeyEnd())
+	}
+	return fmt.Sprintf("%s(%q)", notification.Type, notification.GetKey())
+}
+
+// compareSequenceUpdates checks the received keys are an in-order
+// subsequence of the produced keys that ends with the last one, since Oxia
+// may coalesce updates.
+func compareSequenceUpdates(diff *AssertionDiff, expected []string, actual []string) {
+	if len(expected) == 0 {
+		return
+	}
+	expectIndex := 0
+	for _, key := range actual {
+		for expectIndex < len(expected) && expected[expectIndex] != key {
+			expectIndex++
+		}
+		if expectIndex == len(expected) {
+			diff.Mismatches = append(diff.Mismatches, fmt.Sprintf("unexpected or out of order sequence update %q", key))
+			return
+		}
+		expectIndex++
+	}
+	if lastKey := expected[len(expected)-1]; len(actual) == 0 || actual[len(actual)-1] != lastKey {
+		diff.MissingKeys = append(diff.MissingKeys, lastKey)
+	}
+}
@@ -0,0 +1,184 @@
+package task
+
+import (
+	"hash/crc32"
+	"slices"
+	"testing"
+
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+)
+
+func TestEvaluateAssertion(t *testing.T) {
+	checksum := crc32.Checksum([]byte("value"), castagnoli)
+	length := uint64(len("value"))
+	notFound := proto.ExpectedError_KEY_NOT_FOUND
+	versionConflict := proto.ExpectedError_VERSION_CONFLICT
+	key := "key"
+	otherKey := "other"
+
+	get := &proto.Operation_Get{Get: &proto.OperationGet{Key: "a"}}
+	scan := &proto.Operation_Scan{Scan: &proto.OperationScan{KeyStart: "a", KeyEnd: "z"}}
+	list := &proto.Operation_List{List: &proto.OperationList{KeyStart: "a", KeyEnd: "z"}}
+	put := &proto.Operation_Put{Put: &proto.OperationPut{Key: "a"}}
+
+	for _, test := range []struct {
+		name      string
+		operation *proto.Operation
+		response  *proto.ExecuteResponse
+		expected  *AssertionDiff
+	}{
+		{
+			name:      "no assertion",
+			operation: &proto.Operation{Operation: get},
+			response:  &proto.ExecuteResponse{Records: []*proto.Record{{Key: "b"}}},
+		},
+		{
+			name:      "get matches",
+			operation: &proto.Operation{Operation: get, Assertion: &proto.Assertion{Records: []*proto.Record{{Key: "a", Value: []byte("value")}}}},
+			response:  &proto.ExecuteResponse{Records: []*proto.Record{{Key: "a", Value: []byte("value")}}},
+		},
+		{
+			name:      "get matches the checksum",
+			operation: &proto.Operation{Operation: get, Assertion: &proto.Assertion{Records: []*proto.Record{{Key: "a", ValueChecksum: &checksum, ValueLength: &length}}}},
+			response:  &proto.ExecuteResponse{Records: []*proto.Record{{Key: "a", Value: []byte("value")}}},
+		},
+		{
+			name:      "get mismatches the checksum",
+			operation: &proto.Operation{Operation: get, Assertion: &proto.Assertion{Records: []*proto.Record{{Key: "a", ValueChecksum: &checksum, ValueLength: &length}}}},
+			response:  &proto.ExecuteResponse{Records: []*proto.Record{{Key: "a", Value: []byte("other")}}},
+			expected:  &AssertionDiff{ValueMismatches: []string{"a"}},
+		},
+		{
+			name:      "get misses the key",
+			operation: &proto.Operation{Operation: get, Assertion: &proto.Assertion{Records: []*proto.Record{{Key: "a"}}}},
+			response:  &proto.ExecuteResponse{},
+			expected:  &AssertionDiff{MissingKeys: []string{"a"}},
+		},
+		{
+			name:      "get expects no record",
+			operation: &proto.Operation{Operation: get, Assertion: &proto.Assertion{EmptyRecords: ptr(true)}},
+			response:  &proto.ExecuteResponse{Records: []*proto.Record{{Key: "a"}}},
+			expected:  &AssertionDiff{ExtraKeys: []string{"a"}},
+		},
+		{
+			name: "scan diff",
+			operation: &proto.Operation{Operation: scan, Assertion: &proto.Assertion{Records: []*proto.Record{
+				{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("2")}, {Key: "c", Value: []byte("3")}}}},
+			response: &proto.ExecuteResponse{Records: []*proto.Record{
+				{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("x")}, {Key: "d", Value: []byte("4")}}},
+			expected: &AssertionDiff{MissingKeys: []string{"c"}, ExtraKeys: []string{"d"}, ValueMismatches: []string{"b"}},
+		},
+		{
+			name:      "scan out of order",
+			operation: &proto.Operation{Operation: scan, Assertion: &proto.Assertion{Records: []*proto.Record{{Key: "a"}, {Key: "b"}}}},
+			response:  &proto.ExecuteResponse{Records: []*proto.Record{{Key: "b"}, {Key: "a"}}},
+			expected:  &AssertionDiff{Mismatches: []string{"records out of order"}},
+		},
+		{
+			name:      "list ignores the values",
+			operation: &proto.Operation{Operation: list, Assertion: &proto.Assertion{Records: []*proto.Record{{Key: "a", Value: []byte("1")}}}},
+			response:  &proto.ExecuteResponse{Records: []*proto.Record{{Key: "a"}}},
+		},
+		{
+			name:      "expected error",
+			operation: &proto.Operation{Operation: get, Assertion: &proto.Assertion{ExpectedError: &notFound}},
+			response:  &proto.ExecuteResponse{Error: &notFound},
+		},
+		{
+			name:      "other error",
+			operation: &proto.Operation{Operation: get, Assertion: &proto.Assertion{ExpectedError: &notFound}},
+			response:  &proto.ExecuteResponse{Error: &versionConflict},
+			expected:  &AssertionDiff{Mismatches: []string{"expected error KEY_NOT_FOUND, actual VERSION_CONFLICT"}},
+		},
+		{
+			name:      "missing error",
+			operation: &proto.Operation{Operation: get, Assertion: &proto.Assertion{ExpectedError: &notFound}},
+			response:  &proto.ExecuteResponse{},
+			expected:  &AssertionDiff{Mismatches: []string{"expected error KEY_NOT_FOUND, actual NO_ERROR"}},
+		},
+		{
+			name:      "expected version conflict",
+			operation: &proto.Operation{Operation: put, Assertion: &proto.Assertion{ExpectVersionConflict: ptr(true)}},
+			response:  &proto.ExecuteResponse{},
+			expected:  &AssertionDiff{Mismatches: []string{"expected version conflict but put succeeded"}},
+		},
+		{
+			name:      "unexpected version conflict",
+			operation: &proto.Operation{Operation: put, Assertion: &proto.Assertion{Records: []*proto.Record{{Key: "a"}}}},
+			response:  &proto.ExecuteResponse{VersionConflict: ptr(true)},
+			expected:  &AssertionDiff{Mismatches: []string{"unexpected version conflict"}},
+		},
+		{
+			name: "notification matches",
+			operation: &proto.Operation{Operation: put, Assertion: &proto.Assertion{
+				Notification: &proto.Notification{Type: proto.NotificationType_KEY_CREATED, Key: &key}}},
+			response: &proto.ExecuteResponse{Notification: &proto.Notification{Type: proto.NotificationType_KEY_CREATED, Key: &key}},
+		},
+		{
+			name: "notification missing",
+			operation: &proto.Operation{Operation: put, Assertion: &proto.Assertion{
+				Notification: &proto.Notification{Type: proto.NotificationType_KEY_CREATED, Key: &key}}},
+			response: &proto.ExecuteResponse{},
+			expected: &AssertionDiff{Mismatches: []string{"missing KEY_CREATED notification"}},
+		},
+		{
+			name: "notification mismatches",
+			operation: &proto.Operation{Operation: put, Assertion: &proto.Assertion{
+				Notification: &proto.Notification{Type: proto.NotificationType_KEY_CREATED, Key: &key}}},
+			response: &proto.ExecuteResponse{Notification: &proto.Notification{Type: proto.NotificationType_KEY_MODIFIED, Key: &otherKey}},
+			expected: &AssertionDiff{Mismatches: []string{`mismatched notification, expected KEY_CREATED("key"), actual KEY_MODIFIED("other")`}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			diff := EvaluateAssertion(test.operation, test.response)
+			if !equalDiffs(diff, test.expected) {
+				t.Fatalf("got diff %+v, expected %+v", diff, test.expected)
+			}
+		})
+	}
+}
+
+func TestCompareSequenceUpdates(t *testing.T) {
+	produced := []string{"k1", "k2", "k3", "k4"}
+	for _, test := range []struct {
+		name     string
+		expected []string
+		actual   []string
+		diff     *AssertionDiff
+	}{
+		{name: "every update", expected: produced, actual: []string{"k1", "k2", "k3", "k4"}},
+		{name: "coalesced updates", expected: produced, actual: []string{"k2", "k4"}},
+		{name: "latest update only", expected: produced, actual: []string{"k4"}},
+		{name: "nothing expected", expected: nil, actual: []string{"k1"}},
+		{name: "no update", expected: produced, actual: nil, diff: &AssertionDiff{MissingKeys: []string{"k4"}}},
+		{name: "missing the latest", expected: produced, actual: []string{"k1", "k3"}, diff: &AssertionDiff{MissingKeys: []string{"k4"}}},
+		{name: "out of order", expected: produced, actual: []string{"k3", "k2", "k4"},
+			diff: &AssertionDiff{Mismatches: []string{`unexpected or out of order sequence update "k2"`}}},
+		{name: "duplicated", expected: produced, actual: []string{"k2", "k2", "k4"},
+			diff: &AssertionDiff{Mismatches: []string{`unexpected or out of order sequence update "k2"`}}},
+		{name: "unexpected", expected: produced, actual: []string{"k1", "k9"},
+			diff: &AssertionDiff{Mismatches: []string{`unexpected or out of order sequence update "k9"`}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			diff := EvaluateAssertion(&proto.Operation{
+				Operation: &proto.Operation_SequenceUpdates{SequenceUpdates: &proto.OperationSequenceUpdates{Key: "k"}},
+				Assertion: &proto.Assertion{SequenceUpdates: test.expected},
+			}, &proto.ExecuteResponse{SequenceUpdates: test.actual})
+			if !equalDiffs(diff, test.diff) {
+				t.Fatalf("got diff %+v, expected %+v", diff, test.diff)
+			}
+		})
+	}
+}
+
+func equalDiffs(a, b *AssertionDiff) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return slices.Equal(a.MissingKeys, b.MissingKeys) && slices.Equal(a.ExtraKeys, b.ExtraKeys) &&
+		slices.Equal(a.ValueMismatches, b.ValueMismatches) && slices.Equal(a.Mismatches, b.Mismatches)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
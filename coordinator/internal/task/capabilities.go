@@ -0,0 +1,72 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/oxia-io/okk/coordinator/internal/task/generator"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProtocolVersion is the oldest version of the protocol a worker must implement.
+const ProtocolVersion = 1
+
+var ErrUnsupportedByWorker = errors.New("unsupported by worker")
+
+// checkCapabilities asks the worker what it supports, and returns an
+// ErrUnsupportedByWorker listing what gen needs beyond it in mode. Workers
+// predating the Describe RPC are trusted.
+func checkCapabilities(ctx context.Context, client proto.OkkClient, gen generator.Generator,
+	mode proto.AssertionMode, logger *slog.Logger) error {
+	cg, ok := gen.(generator.CapabilityAwareGenerator)
+	if !ok {
+		return nil
+	}
+	description, err := client.Describe(ctx, &proto.DescribeRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		logger.Warn("Worker does not describe its capabilities, skipping the check")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if missing := missingCapabilities(description, cg.Requirements(), mode); len(missing) > 0 {
+		return errors.Wrapf(ErrUnsupportedByWorker, "%s worker with %s %s lacks %s",
+			description.Implementation, description.ClientLibrary, description.ClientVersion, strings.Join(missing, ", "))
+	}
+	logger.Info("Worker capabilities checked",
+		"implementation", description.Implementation,
+		"clientLibrary", description.ClientLibrary,
+		"clientVersion", description.ClientVersion,
+		"protocolVersion", description.ProtocolVersion)
+	return nil
+}
+
+func missingCapabilities(description *proto.DescribeResponse, requirements generator.Requirements, mode proto.AssertionMode) []string {
+	var missing []string
+	if description.ProtocolVersion < ProtocolVersion {
+		missing = append(missing, fmt.Sprintf("protocol version %d", ProtocolVersion))
+	}
+	if !slices.Contains(description.AssertionModes, mode) {
+		missing = append(missing, "assertion mode "+mode.String())
+	}
+	for _, operation := range requirements.Operations {
+		if !slices.Contains(description.Operations, operation) {
+			missing = append(missing, "operation "+operation)
+		}
+	}
+	if mode == proto.AssertionMode_WORKER {
+		for _, assertion := range requirements.Assertions {
+			if !slices.Contains(description.Assertions, assertion) {
+				missing = append(missing, "assertion "+assertion)
+			}
+		}
+	}
+	return missing
+}
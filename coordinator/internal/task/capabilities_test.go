@@ -0,0 +1,37 @@
+package task
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/oxia-io/okk/coordinator/internal/task/generator"
+)
+
+func TestSecondaryIndexCapabilities(t *testing.T) {
+	gen, err := generator.NewSecondaryIndex(context.Background(), &config.TestCaseConfig{Name: "secondary"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requirements := gen.(generator.CapabilityAwareGenerator).Requirements()
+	description := &proto.DescribeResponse{
+		ProtocolVersion: ProtocolVersion,
+		Operations:      []string{"put", "delete", "deleteRange", "get", "list", "scan"},
+		Assertions:      []string{"get.records", "get.emptyRecords", "list.records", "scan.records"},
+		AssertionModes:  []proto.AssertionMode{proto.AssertionMode_WORKER},
+	}
+
+	// a worker without the index options is rejected
+	missing := missingCapabilities(description, requirements, proto.AssertionMode_WORKER)
+	expected := []string{"operation put.secondaryIndexes", "operation get.useIndex", "operation list.useIndex"}
+	if !slices.Equal(missing, expected) {
+		t.Fatalf("got missing capabilities %v, expected %v", missing, expected)
+	}
+
+	description.Operations = append(description.Operations, "put.secondaryIndexes", "get.useIndex", "list.useIndex")
+	if missing := missingCapabilities(description, requirements, proto.AssertionMode_WORKER); len(missing) > 0 {
+		t.Fatalf("got missing capabilities %v, expected none", missing)
+	}
+}
@@ -0,0 +1,166 @@
+package task
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+)
+
+// EventType is the kind of change an Event reports.
+type EventType string
+
+const (
+	// EventState reports a state transition, including the creation and the
+	// completion of a testcase.
+	EventState EventType = "state"
+	// EventDeleted reports that a testcase was deleted.
+	EventDeleted EventType = "deleted"
+	// EventAssertionFailure reports a failed assertion.
+	EventAssertionFailure EventType = "assertion_failure"
+	// EventRetry reports that an operation is retried.
+	EventRetry EventType = "retry"
+	// EventReconnect reports that the stream to the worker is established again.
+	EventReconnect EventType = "reconnect"
+	// EventNonRetryable reports an operation that failed without retries.
+	EventNonRetryable EventType = "non_retryable"
+)
+
+const (
+	// eventHistorySize bounds how many past events are kept for the
+	// subscribers catching up after a reconnection.
+	eventHistorySize = 1024
+	// eventBufferSize bounds how many events a subscriber can fall behind
+	// before it is dropped.
+	eventBufferSize = 256
+)
+
+// Event is a change of a testcase, published by the task layer as it happens.
+type Event struct {
+	// ID increases with every event, so that a subscriber can resume after
+	// the last one it saw.
+	ID       uint64            `json:"id"`
+	Type     EventType         `json:"type"`
+	Testcase string            `json:"testcase"`
+	Time     time.Time         `json:"time"`
+	State    string            `json:"state,omitempty"`
+	Reason   string            `json:"reason,omitempty"`
+	Sequence int64             `json:"sequence,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	Failure  *AssertionFailure `json:"failure,omitempty"`
+
+	// config and status are the testcase config and a snapshot of its
+	// status when the event was published, for the webhooks. The status is
+	// only set on the state and assertion failure events of the tasks.
+	config *config.TestCaseConfig
+	status *TaskStatus
+}
+
+type subscriber struct {
+	testcase string
+	events   chan *Event
+}
+
+// EventBus fans the events out to the subscribers. A subscriber whose buffer
+// fills up is dropped, its channel is closed, and it can subscribe again
+// from the last event it received.
+type EventBus struct {
+	sync.Mutex
+	nextID      uint64
+	history     []*Event
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+// Publish stamps the event with its id and time, and delivers it.
+func (b *EventBus) Publish(event *Event) {
+	b.Lock()
+	defer b.Unlock()
+	if b.closed {
+		return
+	}
+	b.nextID++
+	event.ID = b.nextID
+	event.Time = time.Now()
+	if len(b.history) == eventHistorySize {
+		b.history = b.history[1:]
+	}
+	b.history = append(b.history, event)
+	for s := range b.subscribers {
+		if s.testcase != "" && s.testcase != event.Testcase {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			b.drop(s)
+		}
+	}
+}
+
+// Subscribe returns the events of testcase, or of every testcase when it is
+// empty, starting after the event afterID. The past events still kept are
+// delivered first. The returned function unsubscribes.
+func (b *EventBus) Subscribe(testcase string, afterID uint64) (<-chan *Event, func()) {
+	b.Lock()
+	defer b.Unlock()
+	s := &subscriber{testcase: testcase, events: make(chan *Event, eventBufferSize+eventHistorySize)}
+	if b.closed {
+		close(s.events)
+		return s.events, func() {}
+	}
+	if afterID > 0 {
+		for _, event := range b.history {
+			if event.ID > afterID && (testcase == "" || event.Testcase == testcase) {
+				s.events <- event
+			}
+		}
+	}
+	b.subscribers[s] = struct{}{}
+	return s.events, func() {
+		b.Lock()
+		defer b.Unlock()
+		b.drop(s)
+	}
+}
+
+// Recent returns up to n of the events of testcase up to the event untilID
+// that are still kept, oldest first.
+func (b *EventBus) Recent(testcase string, untilID uint64, n int) []*Event {
+	b.Lock()
+	defer b.Unlock()
+	var result []*Event
+	for i := len(b.history) - 1; i >= 0 && len(result) < n; i-- {
+		if b.history[i].Testcase == testcase && b.history[i].ID <= untilID {
+			result = append(result, b.history[i])
+		}
+	}
+	slices.Reverse(result)
+	return result
+}
+
+func (b *EventBus) drop(s *subscriber) {
+	if _, ok := b.subscribers[s]; ok {
+		delete(b.subscribers, s)
+		close(s.events)
+	}
+}
+
+// Close ends every subscription, so that the streams do not hold the shutdown.
+func (b *EventBus) Close() {
+	b.Lock()
+	defer b.Unlock()
+	b.closed = true
+	for s := range b.subscribers {
+		b.drop(s)
+	}
+}
+
+// NewEventBus returns an EventBus closed once ctx is done.
+func NewEventBus(ctx context.Context) *EventBus {
+	b := &EventBus{subscribers: make(map[*subscriber]struct{})}
+	context.AfterFunc(ctx, b.Close)
+	return b
+}
@@ -0,0 +1,41 @@
+package task
+
+import (
+	"time"
+
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+)
+
+const (
+	// legacyEventuallyTimeout is the window of the deprecated eventually_empty assertion.
+	legacyEventuallyTimeout = 5 * time.Minute
+	defaultPollInterval     = time.Second
+)
+
+// eventuallyWindow returns the timeout and poll interval of an eventual
+// assertion on a read, or false when a failure of the operation is final.
+// The window of a notification assertion on a write, or of a sequence updates
+// assertion, is not one: the worker waits that long for the notification or
+// the updates before responding, within the timeout of the operation, and the
+// failure is final.
+func eventuallyWindow(operation *proto.Operation) (time.Duration, time.Duration, bool) {
+	switch operation.Operation.(type) {
+	case *proto.Operation_Get, *proto.Operation_List, *proto.Operation_Scan:
+	default:
+		// writes are not idempotent, so they are never executed again, and
+		// their notification window is already waited by the worker
+		return 0, 0, false
+	}
+	assertion := operation.Assertion
+	if eventually := assertion.GetEventually(); eventually != nil {
+		pollInterval := time.Duration(eventually.PollIntervalMillis) * time.Millisecond
+		if pollInterval <= 0 {
+			pollInterval = defaultPollInterval
+		}
+		return time.Duration(eventually.TimeoutMillis) * time.Millisecond, pollInterval, true
+	}
+	if assertion.GetEventuallyEmpty() {
+		return legacyEventuallyTimeout, defaultPollInterval, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,135 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+)
+
+const (
+	// maxFailures bounds how many failures a TaskStatus keeps, the oldest are dropped first.
+	maxFailures = 100
+	// maxFailureRecords bounds how many records of each side an AssertionFailure keeps.
+	maxFailureRecords = 20
+)
+
+// AssertionFailure is the structured report of a failed assertion.
+type AssertionFailure struct {
+	Sequence             int64          `json:"sequence"`
+	Key                  string         `json:"key,omitempty"`
+	Message              string         `json:"message"`
+	Diff                 *AssertionDiff `json:"diff,omitempty"`
+	ExpectedRecords      []FailedRecord `json:"expected_records,omitempty"`
+	ActualRecords        []FailedRecord `json:"actual_records,omitempty"`
+	ExpectedNotification string         `json:"expected_notification,omitempty"`
+	ActualNotification   string         `json:"actual_notification,omitempty"`
+	ExpectedError        string         `json:"expected_error,omitempty"`
+	ActualError          string         `json:"actual_error,omitempty"`
+	OperationTime        *time.Time     `json:"operation_time,omitempty"`
+	ObservedTime         time.Time      `json:"observed_time"`
+	Worker               string         `json:"worker"`
+}
+
+// FailedRecord is a record of an AssertionFailure. Values that are not valid
+// UTF-8 are reported by length only.
+type FailedRecord struct {
+	Key           string  `json:"key"`
+	Value         *string `json:"value,omitempty"`
+	ValueChecksum *string `json:"value_checksum,omitempty"`
+	ValueLength   *uint64 `json:"value_length,omitempty"`
+}
+
+// newAssertionFailure builds the report of a failed assertion, either from
+// the diff computed by the coordinator or from the detail sent by the worker.
+func newAssertionFailure(worker string, operation *proto.Operation, response *proto.ExecuteResponse,
+	diff *AssertionDiff, statusInfo string) *AssertionFailure {
+	detail := response.FailureDetail
+	if detail == nil {
+		detail = &proto.AssertionFailureDetail{
+			ExpectedRecords:      operation.GetAssertion().GetRecords(),
+			ExpectedNotification: operation.GetAssertion().GetNotification(),
+			ExpectedError:        operation.GetAssertion().ExpectedError,
+		}
+		if diff != nil {
+			detail.ActualRecords = response.Records
+			detail.ActualNotification = response.Notification
+			detail.ActualError = response.Error
+		}
+	}
+
+	failure := &AssertionFailure{
+		Sequence:             operation.Sequence,
+		Key:                  detail.Key,
+		Message:              statusInfo,
+		Diff:                 diff,
+		ExpectedRecords:      failedRecords(detail.ExpectedRecords),
+		ActualRecords:        failedRecords(detail.ActualRecords),
+		ExpectedNotification: notificationString(detail.ExpectedNotification),
+		ActualNotification:   notificationString(detail.ActualNotification),
+		ObservedTime:         time.Now(),
+		Worker:               worker,
+	}
+	if detail.ExpectedError != nil {
+		failure.ExpectedError = detail.GetExpectedError().String()
+		failure.ActualError = detail.GetActualError().String()
+	}
+	if failure.Key == "" {
+		failure.Key = operationKey(operation)
+	}
+	if detail.Message != "" {
+		failure.Message = detail.Message
+	}
+	if detail.ObservedTimestamp != 0 {
+		failure.ObservedTime = time.Unix(0, detail.ObservedTimestamp)
+	}
+	if detail.WorkerId != "" {
+		failure.Worker = detail.WorkerId
+	}
+	if timestamp := operation.Timestamp; timestamp != 0 {
+		operationTime := time.Unix(0, timestamp)
+		failure.OperationTime = &operationTime
+	}
+	return failure
+}
+
+// appendFailure returns a copy of failures with failure appended, so that
+// readers of a TaskStatus never observe the slice being modified.
+func appendFailure(failures []*AssertionFailure, failure *AssertionFailure) []*AssertionFailure {
+	if len(failures) >= maxFailures {
+		failures = failures[len(failures)-maxFailures+1:]
+	}
+	return append(slices.Clone(failures), failure)
+}
+
+func failedRecords(records []*proto.Record) []FailedRecord {
+	result := make([]FailedRecord, 0, min(len(records), maxFailureRecords))
+	for _, record := range records[:min(len(records), maxFailureRecords)] {
+		failed := FailedRecord{
+			Key: record.Key,
+		}
+		switch {
+		case record.ValueChecksum != nil:
+			checksum := fmt.Sprintf("%08x", record.GetValueChecksum())
+			failed.ValueChecksum = &checksum
+			failed.ValueLength = record.ValueLength
+		case utf8.Valid(record.Value):
+			value := string(record.Value)
+			failed.Value = &value
+		default:
+			length := uint64(len(record.Value))
+			failed.ValueLength = &length
+		}
+		result = append(result, failed)
+	}
+	return result
+}
+
+func notificationString(notification *proto.Notification) string {
+	if notification == nil {
+		return ""
+	}
+	return formatNotification(notification)
+}
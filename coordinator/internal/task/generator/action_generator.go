@@ -1,8 +1,14 @@
 package generator
 
 import (
+	"fmt"
 	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 type OpType = uint32
@@ -17,31 +23,139 @@ const OpGetLower OpType = 6
 const OpList OpType = 7
 const OpScan OpType = 8
 const OpDeleteRange OpType = 9
+const OpConditionalUpdate OpType = 10
+const OpConflictCreate OpType = 11
+const OpStaleUpdate OpType = 12
+const OpDeleteAndRecreate OpType = 13
+const OpUnconditionalPut OpType = 14
+const OpGetMissing OpType = 15
+const OpDeleteMissing OpType = 16
+const OpInvalidRange OpType = 17
+const OpOversizedPut OpType = 18
+
+const propertiesKeyOpWeights = "opWeights"
+
+// opNames are the names used for each OpType in the opWeights property.
+var opNames = map[string]OpType{
+	"put":               OpPut,
+	"delete":            OpDelete,
+	"get":               OpGet,
+	"getFloor":          OpGetFloor,
+	"getCeiling":        OpGetCeiling,
+	"getHigher":         OpGetHigher,
+	"getLower":          OpGetLower,
+	"list":              OpList,
+	"scan":              OpScan,
+	"deleteRange":       OpDeleteRange,
+	"conditionalUpdate": OpConditionalUpdate,
+	"conflictCreate":    OpConflictCreate,
+	"staleUpdate":       OpStaleUpdate,
+	"deleteAndRecreate": OpDeleteAndRecreate,
+	"unconditionalPut":  OpUnconditionalPut,
+	"getMissing":        OpGetMissing,
+	"deleteMissing":     OpDeleteMissing,
+	"invalidRange":      OpInvalidRange,
+	"oversizedPut":      OpOversizedPut,
+}
+
+var ErrInvalidOpWeights = errors.New("invalid op weights")
 
 type ActionGenerator struct {
-	r   *rand.Rand
-	ops []OpType
+	r                 *rand.Rand
+	ops               []OpType
+	cumulativeWeights []int
+	totalWeights      int
+}
+
+// NewActionGenerator returns an ActionGenerator picking each op with a
+// probability proportional to its weight. Weights are relative, so
+// {put: 1, get: 9} and {put: 10, get: 90} are the same mix.
+func NewActionGenerator(weights map[OpType]int) (*ActionGenerator, error) {
+	ops := make([]OpType, 0, len(weights))
+	for op := range weights {
+		ops = append(ops, op)
+	}
+	slices.Sort(ops)
+
+	g := &ActionGenerator{
+		r: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, op := range ops {
+		weight := weights[op]
+		if weight < 0 {
+			return nil, errors.Wrapf(ErrInvalidOpWeights, "negative weight %d for op %d", weight, op)
+		}
+		if weight == 0 {
+			continue
+		}
+		g.totalWeights += weight
+		g.ops = append(g.ops, op)
+		g.cumulativeWeights = append(g.cumulativeWeights, g.totalWeights)
+	}
+	if g.totalWeights <= 0 {
+		return nil, errors.Wrap(ErrInvalidOpWeights, "at least one op must have a positive weight")
+	}
+	return g, nil
 }
 
-func NewActionGenerator(weights map[OpType]int) *ActionGenerator {
-	ops := make([]OpType, 100)
-	totalWeights := 0
-	for k, v := range weights {
-		for range v {
-			ops[totalWeights] = k
-			totalWeights++
-			if totalWeights > 100 {
-				panic("unexpected action generator weights")
-			}
+// NewActionGeneratorFromProperties builds an ActionGenerator from the
+// opWeights property, e.g. "get=90,put=10", or from defaults when it is
+// absent. Ops omitted from the property are never picked, and ops that are
+// not in defaults are rejected since the generator does not support them.
+func NewActionGeneratorFromProperties(properties map[string]string, defaults map[OpType]int) (*ActionGenerator, error) {
+	value, exist := properties[propertiesKeyOpWeights]
+	if !exist {
+		return NewActionGenerator(defaults)
+	}
+
+	weights := make(map[OpType]int)
+	for _, pair := range strings.Split(value, ",") {
+		name, weightStr, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return nil, errors.Wrapf(ErrInvalidOpWeights, "malformed entry %q, expected <op>=<weight>", pair)
+		}
+		op, known := opNames[name]
+		if !known {
+			return nil, errors.Wrapf(ErrInvalidOpWeights, "unknown op %q", name)
+		}
+		if _, supported := defaults[op]; !supported {
+			return nil, errors.Wrapf(ErrInvalidOpWeights, "op %q is not supported by this testcase type, supported ops: %s", name, supportedOpNames(defaults))
+		}
+		if _, duplicated := weights[op]; duplicated {
+			return nil, errors.Wrapf(ErrInvalidOpWeights, "duplicated op %q", name)
+		}
+		weight, err := strconv.Atoi(weightStr)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidOpWeights, "malformed weight %q for op %q", weightStr, name)
 		}
+		weights[op] = weight
 	}
-	return &ActionGenerator{
-		r:   rand.New(rand.NewSource(time.Now().UnixNano())),
-		ops: ops,
+	return NewActionGenerator(weights)
+}
+
+// rejectOpWeights fails on the opWeights property for the generators whose
+// operations follow a fixed sequence, instead of ignoring it.
+func rejectOpWeights(properties map[string]string) error {
+	if _, exist := properties[propertiesKeyOpWeights]; exist {
+		return errors.Wrapf(ErrInvalidOpWeights, "%s is not supported by this testcase type, its operations follow a fixed sequence",
+			propertiesKeyOpWeights)
+	}
+	return nil
+}
+
+func supportedOpNames(defaults map[OpType]int) string {
+	names := make([]string, 0, len(defaults))
+	for name, op := range opNames {
+		if _, supported := defaults[op]; supported {
+			names = append(names, name)
+		}
 	}
+	slices.Sort(names)
+	return fmt.Sprint(names)
 }
 
 func (g *ActionGenerator) Next() OpType {
-	target := g.r.Intn(100)
-	return g.ops[target]
+	target := g.r.Intn(g.totalWeights)
+	idx, _ := slices.BinarySearch(g.cumulativeWeights, target+1)
+	return g.ops[idx]
 }
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+)
+
+func TestNewActionGeneratorFromProperties(t *testing.T) {
+	defaults := map[OpType]int{OpPut: 40, OpDelete: 10, OpGet: 50}
+	for _, test := range []struct {
+		name      string
+		opWeights *string
+		ops       []OpType
+		weights   []int
+		invalid   bool
+	}{
+		{name: "defaults", ops: []OpType{OpPut, OpDelete, OpGet}, weights: []int{40, 50, 100}},
+		{name: "subset", opWeights: ptr("get=90,put=10"), ops: []OpType{OpPut, OpGet}, weights: []int{10, 100}},
+		{name: "spaces", opWeights: ptr(" get=1 , delete=3"), ops: []OpType{OpDelete, OpGet}, weights: []int{3, 4}},
+		{name: "zero weight", opWeights: ptr("get=0,put=1"), ops: []OpType{OpPut}, weights: []int{1}},
+		{name: "all zero", opWeights: ptr("get=0,put=0"), invalid: true},
+		{name: "negative", opWeights: ptr("get=-1,put=2"), invalid: true},
+		{name: "empty", opWeights: ptr(""), invalid: true},
+		{name: "malformed entry", opWeights: ptr("get"), invalid: true},
+		{name: "malformed weight", opWeights: ptr("get=x"), invalid: true},
+		{name: "unknown op", opWeights: ptr("fly=1"), invalid: true},
+		{name: "unsupported op", opWeights: ptr("scan=1"), invalid: true},
+		{name: "duplicated op", opWeights: ptr("get=1,get=2"), invalid: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			properties := map[string]string{}
+			if test.opWeights != nil {
+				properties[propertiesKeyOpWeights] = *test.opWeights
+			}
+			g, err := NewActionGeneratorFromProperties(properties, defaults)
+			if test.invalid {
+				if !errors.Is(err, ErrInvalidOpWeights) {
+					t.Fatalf("expected ErrInvalidOpWeights, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(g.ops, test.ops) || !slices.Equal(g.cumulativeWeights, test.weights) {
+				t.Fatalf("got ops %v with cumulative weights %v, expected %v with %v", g.ops, g.cumulativeWeights, test.ops, test.weights)
+			}
+			for range 1000 {
+				if op := g.Next(); !slices.Contains(test.ops, op) {
+					t.Fatalf("picked op %d, expected one of %v", op, test.ops)
+				}
+			}
+		})
+	}
+}
+
+func TestOpWeightsRejectedByFixedSequences(t *testing.T) {
+	for name, constructor := range map[string]func(context.Context, *config.TestCaseConfig) (Generator, error){
+		"streaming-sequence": NewStreamingSequence,
+		"metadata-ephemeral": NewMetadataEphemeralGenerator,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := constructor(context.Background(), &config.TestCaseConfig{
+				Name:       name,
+				Properties: map[string]string{propertiesKeyOpWeights: "put=1"},
+			})
+			if !errors.Is(err, ErrInvalidOpWeights) {
+				t.Fatalf("expected ErrInvalidOpWeights, got %v", err)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
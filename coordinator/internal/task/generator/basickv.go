@@ -6,86 +6,80 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"strconv"
-	"time"
+	"strings"
 
-	"github.com/google/uuid"
 	"github.com/oxia-io/okk/coordinator/internal/config"
 	"github.com/oxia-io/okk/coordinator/internal/proto"
-	"golang.org/x/time/rate"
 )
 
 const propertiesKeyKeySpace = "keySpace"
 
-var _ Generator = &basicKv{}
+var _ ResyncableWorkload = &basicKv{}
 
 type basicKv struct {
-	ctx    context.Context
-	cancel context.CancelFunc
 	name   string
 	logger *slog.Logger
 
-	duration        *time.Duration
-	rateLimit       *rate.Limiter
-	startTime       time.Time
 	keySpace        int64
+	keyChooser      KeyChooser
+	values          *ValueGenerator
 	actionGenerator *ActionGenerator
 
 	sequence int64
+	verified int64
 
-	initialized  bool
-	needsCleanup bool
-	data         *DataTree
+	data *DataTree
 }
 
 func (b *basicKv) Name() string {
 	return "basic-kv"
 }
 
-func (b *basicKv) Next() (*proto.Operation, bool) {
-	if b.needsCleanup {
-		b.needsCleanup = false
-		b.logger.Info("Cleaning up stale data from previous run", "prefix", b.name)
-		keyStart := b.name
-		keyEnd := b.name + "~"
-		return &proto.Operation{
-			Operation: &proto.Operation_DeleteRange{
-				DeleteRange: &proto.OperationDeleteRange{
-					KeyStart: keyStart,
-					KeyEnd:   keyEnd,
-				},
-			},
-		}, true
+func (b *basicKv) Requirements() Requirements {
+	return Requirements{
+		Operations: []string{"put", "delete", "deleteRange", "get", "list", "scan"},
+		Assertions: []string{"get.records", "get.emptyRecords", "list.records", "scan.records"},
 	}
+}
 
-	if b.duration != nil && time.Since(b.startTime) > *b.duration {
-		b.logger.Info("Finish the basic kv generator", "name", b.name)
-		return nil, false
-	}
-	if err := b.rateLimit.Wait(b.ctx); err != nil {
-		b.logger.Error("Failed to wait for rate limiter", "error", err)
-		return nil, false
-	}
+func (b *basicKv) KeyRange() (string, string) {
+	return b.name, b.name + "~"
+}
 
-	if !b.initialized {
-		return b.processInitStage()
+func (b *basicKv) Load() (*proto.Operation, bool) {
+	if b.sequence > b.keySpace {
+		return nil, false
 	}
+	return b.processInitStage()
+}
 
+func (b *basicKv) Steady() (*proto.Operation, bool) {
 	return b.processDataValidation()
 }
 
+func (b *basicKv) Verify(batchSize int) (*proto.Operation, bool) {
+	if b.verified > b.keySpace {
+		return nil, false
+	}
+	start := b.verified
+	b.verified += int64(batchSize)
+	return verifyIndexedKeys(b.name, b.data, b.values, start, b.verified, b.keySpace), true
+}
+
 func (b *basicKv) processDataValidation() (*proto.Operation, bool) {
 	action := b.actionGenerator.Next()
-	keyIndex := rand.Int64N(b.keySpace)
+	keyIndex := b.keyChooser.Next()
 	switch action {
 	case OpPut:
 		{
-			uid := uuid.New().String()
-			b.data.Put(makeFormatInt64(keyIndex), uid)
+			b.keyChooser.OnWrite(keyIndex)
+			value, digest := b.values.Next()
+			b.data.Put(makeFormatInt64(keyIndex), digest)
 			return &proto.Operation{
 				Operation: &proto.Operation_Put{
 					Put: &proto.OperationPut{
 						Key:   makeKey(b.name, keyIndex),
-						Value: makeValue(b.name, uid),
+						Value: value,
 					},
 				},
 			}, true
@@ -126,12 +120,7 @@ func (b *basicKv) processDataValidation() (*proto.Operation, bool) {
 				EmptyRecords: &emptyRecord,
 			}
 			if found {
-				assertion.Records = []*proto.Record{
-					{
-						Key:   key,
-						Value: makeValue(b.name, value),
-					},
-				}
+				assertion.Records = []*proto.Record{b.values.Record(key, value)}
 			}
 			return &proto.Operation{
 				Assertion: assertion,
@@ -153,12 +142,7 @@ func (b *basicKv) processDataValidation() (*proto.Operation, bool) {
 				EmptyRecords: &emptyRecord,
 			}
 			if found {
-				assertion.Records = []*proto.Record{
-					{
-						Key:   makeKeyWithFormattedIndex(b.name, entry.Key),
-						Value: makeValue(b.name, entry.Value),
-					},
-				}
+				assertion.Records = []*proto.Record{b.values.Record(makeKeyWithFormattedIndex(b.name, entry.Key), entry.Value)}
 			}
 			return &proto.Operation{
 				Assertion: assertion,
@@ -180,12 +164,7 @@ func (b *basicKv) processDataValidation() (*proto.Operation, bool) {
 				EmptyRecords: &emptyRecord,
 			}
 			if found {
-				assertion.Records = []*proto.Record{
-					{
-						Key:   makeKeyWithFormattedIndex(b.name, entry.Key),
-						Value: makeValue(b.name, entry.Value),
-					},
-				}
+				assertion.Records = []*proto.Record{b.values.Record(makeKeyWithFormattedIndex(b.name, entry.Key), entry.Value)}
 			}
 			return &proto.Operation{
 				Assertion: assertion,
@@ -206,12 +185,7 @@ func (b *basicKv) processDataValidation() (*proto.Operation, bool) {
 				EmptyRecords: &emptyRecord,
 			}
 			if found {
-				assertion.Records = []*proto.Record{
-					{
-						Key:   makeKeyWithFormattedIndex(b.name, entry.Key),
-						Value: makeValue(b.name, entry.Value),
-					},
-				}
+				assertion.Records = []*proto.Record{b.values.Record(makeKeyWithFormattedIndex(b.name, entry.Key), entry.Value)}
 			}
 			return &proto.Operation{
 				Assertion: assertion,
@@ -231,12 +205,7 @@ func (b *basicKv) processDataValidation() (*proto.Operation, bool) {
 				EmptyRecords: &emptyRecord,
 			}
 			if found {
-				assertion.Records = []*proto.Record{
-					{
-						Key:   makeKeyWithFormattedIndex(b.name, entry.Key),
-						Value: makeValue(b.name, entry.Value),
-					},
-				}
+				assertion.Records = []*proto.Record{b.values.Record(makeKeyWithFormattedIndex(b.name, entry.Key), entry.Value)}
 			}
 			return &proto.Operation{
 				Assertion: assertion,
@@ -281,10 +250,7 @@ func (b *basicKv) processDataValidation() (*proto.Operation, bool) {
 			entries := b.data.RangeScan(makeFormatInt64(keyStart), makeFormatInt64(keyEnd))
 			records := make([]*proto.Record, 0)
 			for _, entry := range entries {
-				records = append(records, &proto.Record{
-					Key:   makeKeyWithFormattedIndex(b.name, entry.Key),
-					Value: makeValue(b.name, entry.Value),
-				})
+				records = append(records, b.values.Record(makeKeyWithFormattedIndex(b.name, entry.Key), entry.Value))
 			}
 
 			return &proto.Operation{
@@ -305,22 +271,78 @@ func (b *basicKv) processDataValidation() (*proto.Operation, bool) {
 
 func (b *basicKv) processInitStage() (*proto.Operation, bool) {
 	sequence := b.nextSequence()
-	data := uuid.New().String()
-	b.data.Put(makeFormatInt64(sequence), data)
+	value, digest := b.values.Next()
+	b.data.Put(makeFormatInt64(sequence), digest)
+	b.keyChooser.OnWrite(sequence)
 
-	if sequence >= b.keySpace {
-		b.initialized = true
-	}
 	return &proto.Operation{
 		Operation: &proto.Operation_Put{
 			Put: &proto.OperationPut{
 				Key:   makeKey(b.name, sequence),
-				Value: makeValue(b.name, data),
+				Value: value,
 			},
 		},
 	}, true
 }
 
+func (b *basicKv) ResyncRanges(batchSize int) []ScanRange {
+	return indexedKeyRanges(b.name, batchSize, b.keySpace)
+}
+
+func (b *basicKv) Resync(records []*proto.Record) {
+	b.data = NewDataTree()
+	for _, record := range records {
+		if index, ok := parseKeyIndex(b.name, record.Key); ok {
+			b.data.Put(makeFormatInt64(index), b.values.Digest(record.Value))
+		}
+	}
+}
+
+// parseKeyIndex returns the index of a key made with makeKey, or false for
+// keys of other testcases sharing the name as a prefix.
+func parseKeyIndex(name string, key string) (int64, bool) {
+	formatted, found := strings.CutPrefix(key, name+"-")
+	if !found || len(formatted) != 20 {
+		return 0, false
+	}
+	index, err := strconv.ParseInt(formatted, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return index, true
+}
+
+// verifyIndexedKeys returns a scan of the keys made with makeKey for the
+// indexes in [start, end), asserting them against a model keyed with
+// makeFormatInt64. Once end passes lastIndex the scan is left open ended, so
+// that keys the model does not know about are caught as well.
+func verifyIndexedKeys(name string, data *DataTree, values *ValueGenerator, start int64, end int64, lastIndex int64) *proto.Operation {
+	records := make([]*proto.Record, 0)
+	for _, entry := range data.RangeScan(makeFormatInt64(start), makeFormatInt64(end)) {
+		records = append(records, values.Record(makeKeyWithFormattedIndex(name, entry.Key), entry.Value))
+	}
+	keyEnd := makeKey(name, end)
+	if end > lastIndex {
+		// '.' follows the '-' separator, so this bounds every key of the testcase.
+		keyEnd = name + "."
+	}
+	return scan(makeKey(name, start), keyEnd, records)
+}
+
+// indexedKeyRanges splits the keys of indexedKeys into ranges of batchSize
+// indexes, bounded as the scans of verifyIndexedKeys.
+func indexedKeyRanges(name string, batchSize int, lastIndex int64) []ScanRange {
+	var ranges []ScanRange
+	for start := int64(0); start <= lastIndex; start += int64(batchSize) {
+		keyEnd := makeKey(name, start+int64(batchSize))
+		if start+int64(batchSize) > lastIndex {
+			keyEnd = name + "."
+		}
+		ranges = append(ranges, ScanRange{Start: makeKey(name, start), End: keyEnd})
+	}
+	return ranges
+}
+
 func makeFormatInt64(value int64) string {
 	return fmt.Sprintf("%020d", value)
 }
@@ -341,8 +363,7 @@ func (b *basicKv) nextSequence() int64 {
 	return nextSequence
 }
 
-func NewBasicKv(ctx context.Context, tc *config.TestCaseConfig) Generator {
-	currentContext, currentContextCanceled := context.WithCancel(ctx)
+func NewBasicKv(ctx context.Context, tc *config.TestCaseConfig) (Generator, error) {
 	logger := slog.With("generator", "basic-kv", "name", tc.Name)
 	logger.Info("Starting basic kv generator")
 
@@ -358,8 +379,7 @@ func NewBasicKv(ctx context.Context, tc *config.TestCaseConfig) Generator {
 		}
 	}
 
-	opRate := tc.GetOpRate()
-	actionGenerator := NewActionGenerator(map[OpType]int{
+	actionGenerator, err := NewActionGeneratorFromProperties(tc.Properties, map[OpType]int{
 		OpPut:         10,
 		OpDelete:      10,
 		OpGet:         10,
@@ -371,20 +391,26 @@ func NewBasicKv(ctx context.Context, tc *config.TestCaseConfig) Generator {
 		OpScan:        10,
 		OpDeleteRange: 10,
 	})
+	if err != nil {
+		return nil, err
+	}
+	values, err := NewValueGenerator(tc.Name, tc.Properties, logger)
+	if err != nil {
+		return nil, err
+	}
+	keyChooser, err := NewKeyChooser(keySpace, tc.Properties)
+	if err != nil {
+		return nil, err
+	}
 	bkv := basicKv{
 		logger:          logger,
-		ctx:             currentContext,
-		cancel:          currentContextCanceled,
 		actionGenerator: actionGenerator,
 		name:            tc.Name,
-		duration:        tc.GetDuration(),
-		startTime:       time.Now(),
 		sequence:        0,
-		initialized:     false,
-		needsCleanup:    true,
 		keySpace:        keySpace,
-		rateLimit:       rate.NewLimiter(rate.Limit(opRate), opRate),
+		keyChooser:      keyChooser,
+		values:          values,
 		data:            NewDataTree(),
 	}
-	return &bkv
+	return NewPhasedGenerator(ctx, tc, &bkv, logger)
 }
@@ -3,37 +3,46 @@ package generator
 import (
 	"context"
 	"log/slog"
-	"math/rand/v2"
 	"strconv"
-	"time"
 
-	"github.com/google/uuid"
 	"github.com/oxia-io/okk/coordinator/internal/config"
 	"github.com/oxia-io/okk/coordinator/internal/proto"
-	"golang.org/x/time/rate"
 )
 
-var _ ResponseAwareGenerator = &conditionalPut{}
+var _ ResyncableWorkload = &conditionalPut{}
 
 type keyState struct {
 	versionId int64
-	value     string
+	// value is the digest returned by the ValueGenerator.
+	value string
 }
 
+// pendingAction is the kind of the last operation, which decides how
+// OnResponse updates the version cache.
+type pendingAction int
+
+const (
+	actionCreate pendingAction = iota
+	actionUpdate
+	actionDelete
+	actionConflictCreate
+	actionStaleUpdate
+	// actionRead leaves the version cache as it is, whatever the response.
+	actionRead
+)
+
 type conditionalPut struct {
-	ctx    context.Context
-	cancel context.CancelFunc
 	name   string
 	logger *slog.Logger
 
-	duration  *time.Duration
-	rateLimit *rate.Limiter
-	startTime time.Time
-	keySpace  int64
+	keySpace   int64
+	keyChooser KeyChooser
+	values     *ValueGenerator
+
+	actionGenerator *ActionGenerator
 
-	needsCleanup bool
-	initialized  bool
-	sequence     int64
+	sequence int64
+	verified int64
 
 	// Track version per key. Missing key means key doesn't exist in Oxia.
 	keys map[int64]*keyState
@@ -41,7 +50,7 @@ type conditionalPut struct {
 	// pendingKey tracks which key the last operation targeted,
 	// so OnResponse can update the version cache.
 	pendingKeyIndex int64
-	pendingAction   int // 0=create, 1=update, 2=delete, 3=conflictCreate, 4=staleUpdate, 5=get
+	pendingAction   pendingAction
 	pendingValue    string
 }
 
@@ -49,6 +58,13 @@ func (c *conditionalPut) Name() string {
 	return "conditional-put"
 }
 
+func (c *conditionalPut) Requirements() Requirements {
+	return Requirements{
+		Operations: []string{"put", "delete", "get"},
+		Assertions: []string{"put.expectedError", "get.records", "get.emptyRecords"},
+	}
+}
+
 func (c *conditionalPut) OnResponse(resp *proto.ExecuteResponse) {
 	if resp.VersionId == nil {
 		return
@@ -56,65 +72,87 @@ func (c *conditionalPut) OnResponse(resp *proto.ExecuteResponse) {
 	vid := *resp.VersionId
 
 	switch c.pendingAction {
-	case 0: // create
+	case actionCreate:
 		c.keys[c.pendingKeyIndex] = &keyState{versionId: vid, value: c.pendingValue}
-	case 1: // update
+		c.keyChooser.OnWrite(c.pendingKeyIndex)
+	case actionUpdate:
 		if ks, ok := c.keys[c.pendingKeyIndex]; ok {
 			ks.versionId = vid
 			ks.value = c.pendingValue
 		}
-	// case 2 (delete): state already updated eagerly in genDeleteAndRecreate
-	// case 3,4 (conflict ops): state doesn't change
+		c.keyChooser.OnWrite(c.pendingKeyIndex)
+	// actionDelete: state already updated eagerly in genDeleteAndRecreate
+	// actionConflictCreate, actionStaleUpdate: state doesn't change
 	}
 }
 
-func (c *conditionalPut) Next() (*proto.Operation, bool) {
-	if c.needsCleanup {
-		c.needsCleanup = false
-		c.logger.Info("Cleaning up stale data from previous run", "prefix", c.name)
-		return &proto.Operation{
-			Operation: &proto.Operation_DeleteRange{
-				DeleteRange: &proto.OperationDeleteRange{
-					KeyStart: c.name,
-					KeyEnd:   c.name + "~",
-				},
-			},
-		}, true
-	}
+func (c *conditionalPut) KeyRange() (string, string) {
+	return c.name, c.name + "~"
+}
 
-	if c.duration != nil && time.Since(c.startTime) > *c.duration {
-		c.logger.Info("Finished conditional-put generator", "name", c.name)
+func (c *conditionalPut) Load() (*proto.Operation, bool) {
+	if c.sequence >= c.keySpace {
 		return nil, false
 	}
-	if err := c.rateLimit.Wait(c.ctx); err != nil {
+	return c.processInitStage()
+}
+
+func (c *conditionalPut) Steady() (*proto.Operation, bool) {
+	return c.processValidation()
+}
+
+func (c *conditionalPut) Verify(batchSize int) (*proto.Operation, bool) {
+	if c.verified >= c.keySpace {
 		return nil, false
 	}
-
-	if !c.initialized {
-		return c.processInitStage()
+	start := c.verified
+	c.verified += int64(batchSize)
+	// the pending action is still the one of the last steady operation, so
+	// a response of the scan carrying a version would be applied to its key
+	c.pendingAction = actionRead
+
+	records := make([]*proto.Record, 0)
+	for idx := start; idx < min(c.verified, c.keySpace); idx++ {
+		if ks, ok := c.keys[idx]; ok {
+			records = append(records, c.values.Record(makeKey(c.name, idx), ks.value))
+		}
+	}
+	keyEnd := makeKey(c.name, c.verified)
+	if c.verified >= c.keySpace {
+		keyEnd = c.name + "."
 	}
+	return scan(makeKey(c.name, start), keyEnd, records), true
+}
 
-	return c.processValidation()
+func (c *conditionalPut) ResyncRanges(batchSize int) []ScanRange {
+	return indexedKeyRanges(c.name, batchSize, c.keySpace-1)
+}
+
+// Resync rebuilds the version cache too, so the records need their version id.
+func (c *conditionalPut) Resync(records []*proto.Record) {
+	c.keys = make(map[int64]*keyState)
+	for _, record := range records {
+		if index, ok := parseKeyIndex(c.name, record.Key); ok {
+			c.keys[index] = &keyState{versionId: record.GetVersionId(), value: c.values.Digest(record.Value)}
+		}
+	}
 }
 
 func (c *conditionalPut) processInitStage() (*proto.Operation, bool) {
 	idx := c.sequence
 	c.sequence++
 
-	uid := uuid.New().String()
+	value, digest := c.values.Next()
 	c.pendingKeyIndex = idx
-	c.pendingAction = 0
-	c.pendingValue = uid
+	c.pendingAction = actionCreate
+	c.pendingValue = digest
 
 	versionId := int64(-1) // must not exist
-	if c.sequence >= c.keySpace {
-		c.initialized = true
-	}
 	return &proto.Operation{
 		Operation: &proto.Operation_Put{
 			Put: &proto.OperationPut{
 				Key:               makeKey(c.name, idx),
-				Value:             makeValue(c.name, uid),
+				Value:             value,
 				ExpectedVersionId: &versionId,
 			},
 		},
@@ -122,24 +160,21 @@ func (c *conditionalPut) processInitStage() (*proto.Operation, bool) {
 }
 
 func (c *conditionalPut) processValidation() (*proto.Operation, bool) {
-	// Weighted random action selection:
-	// 25% conditional update, 25% get, 15% conflict create, 15% stale update, 10% delete+recreate, 10% unconditional put
-	roll := rand.IntN(100)
-
-	switch {
-	case roll < 25:
+	switch c.actionGenerator.Next() {
+	case OpConditionalUpdate:
 		return c.genConditionalUpdate()
-	case roll < 50:
+	case OpGet:
 		return c.genGet()
-	case roll < 65:
+	case OpConflictCreate:
 		return c.genConflictCreate()
-	case roll < 80:
+	case OpStaleUpdate:
 		return c.genStaleUpdate()
-	case roll < 90:
+	case OpDeleteAndRecreate:
 		return c.genDeleteAndRecreate()
-	default:
+	case OpUnconditionalPut:
 		return c.genUnconditionalPut()
 	}
+	return nil, false
 }
 
 // genConditionalUpdate: put with correct expected_version_id on an existing key.
@@ -150,25 +185,20 @@ func (c *conditionalPut) genConditionalUpdate() (*proto.Operation, bool) {
 		return c.genGet()
 	}
 
-	uid := uuid.New().String()
+	value, digest := c.values.Next()
 	c.pendingKeyIndex = idx
-	c.pendingAction = 1
-	c.pendingValue = uid
+	c.pendingAction = actionUpdate
+	c.pendingValue = digest
 
 	vid := ks.versionId
 	return &proto.Operation{
 		Assertion: &proto.Assertion{
-			Records: []*proto.Record{
-				{
-					Key:   makeKey(c.name, idx),
-					Value: makeValue(c.name, uid),
-				},
-			},
+			Records: []*proto.Record{c.values.Record(makeKey(c.name, idx), digest)},
 		},
 		Operation: &proto.Operation_Put{
 			Put: &proto.OperationPut{
 				Key:               makeKey(c.name, idx),
-				Value:             makeValue(c.name, uid),
+				Value:             value,
 				ExpectedVersionId: &vid,
 			},
 		},
@@ -177,9 +207,9 @@ func (c *conditionalPut) genConditionalUpdate() (*proto.Operation, bool) {
 
 // genGet: get a key and assert its value.
 func (c *conditionalPut) genGet() (*proto.Operation, bool) {
-	idx := rand.Int64N(c.keySpace)
+	idx := c.keyChooser.Next()
 	c.pendingKeyIndex = idx
-	c.pendingAction = 5
+	c.pendingAction = actionRead
 
 	ks, exists := c.keys[idx]
 	emptyRecord := !exists
@@ -188,12 +218,7 @@ func (c *conditionalPut) genGet() (*proto.Operation, bool) {
 		EmptyRecords: &emptyRecord,
 	}
 	if exists {
-		assertion.Records = []*proto.Record{
-			{
-				Key:   makeKey(c.name, idx),
-				Value: makeValue(c.name, ks.value),
-			},
-		}
+		assertion.Records = []*proto.Record{c.values.Record(makeKey(c.name, idx), ks.value)}
 	}
 	return &proto.Operation{
 		Assertion: assertion,
@@ -215,19 +240,19 @@ func (c *conditionalPut) genConflictCreate() (*proto.Operation, bool) {
 	}
 
 	c.pendingKeyIndex = idx
-	c.pendingAction = 3
+	c.pendingAction = actionConflictCreate
 
-	uid := uuid.New().String()
+	value, _ := c.values.Next()
 	versionId := int64(-1)
-	expectConflict := true
+	expectedError := proto.ExpectedError_VERSION_CONFLICT
 	return &proto.Operation{
 		Assertion: &proto.Assertion{
-			ExpectVersionConflict: &expectConflict,
+			ExpectedError: &expectedError,
 		},
 		Operation: &proto.Operation_Put{
 			Put: &proto.OperationPut{
 				Key:               makeKey(c.name, idx),
-				Value:             makeValue(c.name, uid),
+				Value:             value,
 				ExpectedVersionId: &versionId,
 			},
 		},
@@ -242,23 +267,23 @@ func (c *conditionalPut) genStaleUpdate() (*proto.Operation, bool) {
 	}
 
 	c.pendingKeyIndex = idx
-	c.pendingAction = 4
+	c.pendingAction = actionStaleUpdate
 
-	uid := uuid.New().String()
+	value, _ := c.values.Next()
 	// Use a stale version (current version - 1, or 0 if version is 0)
 	staleVersion := ks.versionId - 1
 	if staleVersion < 0 {
 		staleVersion = ks.versionId + 999999
 	}
-	expectConflict := true
+	expectedError := proto.ExpectedError_VERSION_CONFLICT
 	return &proto.Operation{
 		Assertion: &proto.Assertion{
-			ExpectVersionConflict: &expectConflict,
+			ExpectedError: &expectedError,
 		},
 		Operation: &proto.Operation_Put{
 			Put: &proto.OperationPut{
 				Key:               makeKey(c.name, idx),
-				Value:             makeValue(c.name, uid),
+				Value:             value,
 				ExpectedVersionId: &staleVersion,
 			},
 		},
@@ -273,7 +298,7 @@ func (c *conditionalPut) genDeleteAndRecreate() (*proto.Operation, bool) {
 	}
 
 	c.pendingKeyIndex = idx
-	c.pendingAction = 2
+	c.pendingAction = actionDelete
 
 	delete(c.keys, idx)
 	return &proto.Operation{
@@ -293,17 +318,17 @@ func (c *conditionalPut) genUnconditionalPut() (*proto.Operation, bool) {
 		return c.genGet()
 	}
 
-	uid := uuid.New().String()
+	value, digest := c.values.Next()
 	c.pendingKeyIndex = idx
-	c.pendingAction = 0
-	c.pendingValue = uid
+	c.pendingAction = actionCreate
+	c.pendingValue = digest
 
 	versionId := int64(-1) // must not exist
 	return &proto.Operation{
 		Operation: &proto.Operation_Put{
 			Put: &proto.OperationPut{
 				Key:               makeKey(c.name, idx),
-				Value:             makeValue(c.name, uid),
+				Value:             value,
 				ExpectedVersionId: &versionId,
 			},
 		},
@@ -313,7 +338,7 @@ func (c *conditionalPut) genUnconditionalPut() (*proto.Operation, bool) {
 func (c *conditionalPut) pickExistingKey() (int64, *keyState) {
 	// Try random keys up to 10 times to find an existing one
 	for range 10 {
-		idx := rand.Int64N(c.keySpace)
+		idx := c.keyChooser.Next()
 		if ks, ok := c.keys[idx]; ok {
 			return idx, ks
 		}
@@ -327,7 +352,7 @@ func (c *conditionalPut) pickExistingKey() (int64, *keyState) {
 
 func (c *conditionalPut) pickDeletedKey() int64 {
 	for range 20 {
-		idx := rand.Int64N(c.keySpace)
+		idx := c.keyChooser.Next()
 		if _, ok := c.keys[idx]; !ok {
 			return idx
 		}
@@ -336,8 +361,7 @@ func (c *conditionalPut) pickDeletedKey() int64 {
 	return -1
 }
 
-func NewConditionalPut(ctx context.Context, tc *config.TestCaseConfig) Generator {
-	currentCtx, cancel := context.WithCancel(ctx)
+func NewConditionalPut(ctx context.Context, tc *config.TestCaseConfig) (Generator, error) {
 	logger := slog.With("generator", "conditional-put", "name", tc.Name)
 	logger.Info("Starting conditional-put generator")
 
@@ -353,18 +377,32 @@ func NewConditionalPut(ctx context.Context, tc *config.TestCaseConfig) Generator
 		}
 	}
 
-	opRate := tc.GetOpRate()
-	return &conditionalPut{
-		ctx:          currentCtx,
-		cancel:       cancel,
-		name:         tc.Name,
-		logger:       logger,
-		duration:     tc.GetDuration(),
-		rateLimit:    rate.NewLimiter(rate.Limit(opRate), opRate),
-		startTime:    time.Now(),
-		keySpace:     keySpace,
-		needsCleanup: true,
-		keys:         make(map[int64]*keyState),
+	actionGenerator, err := NewActionGeneratorFromProperties(tc.Properties, map[OpType]int{
+		OpConditionalUpdate: 25,
+		OpGet:               25,
+		OpConflictCreate:    15,
+		OpStaleUpdate:       15,
+		OpDeleteAndRecreate: 10,
+		OpUnconditionalPut:  10,
+	})
+	if err != nil {
+		return nil, err
+	}
+	values, err := NewValueGenerator(tc.Name, tc.Properties, logger)
+	if err != nil {
+		return nil, err
 	}
+	keyChooser, err := NewKeyChooser(keySpace, tc.Properties)
+	if err != nil {
+		return nil, err
+	}
+	return NewPhasedGenerator(ctx, tc, &conditionalPut{
+		name:            tc.Name,
+		logger:          logger,
+		keySpace:        keySpace,
+		keyChooser:      keyChooser,
+		values:          values,
+		actionGenerator: actionGenerator,
+		keys:            make(map[int64]*keyState),
+	}, logger)
 }
-
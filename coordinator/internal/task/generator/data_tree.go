@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"strings"
+
 	"github.com/emirpasic/gods/v2/trees/redblacktree"
 )
 
@@ -158,3 +160,45 @@ func NewDataTree() *DataTree {
 		data: tree,
 	}
 }
+
+// NewHierarchicalDataTree returns a DataTree ordered like Oxia orders keys,
+// see CompareWithSlash.
+func NewHierarchicalDataTree() *DataTree {
+	tree := redblacktree.NewWith[string, string](CompareWithSlash)
+	return &DataTree{
+		data: tree,
+	}
+}
+
+// CompareWithSlash mirrors the key comparator of the Oxia storage engine.
+// Keys are compared segment by segment, where '/' separates segments, and a
+// key with fewer remaining segments sorts before one with more. For example,
+// "/a/z" < "/a/b/c" because the first one has fewer segments.
+func CompareWithSlash(a string, b string) int {
+	for len(a) > 0 && len(b) > 0 {
+		idxA, idxB := strings.IndexByte(a, '/'), strings.IndexByte(b, '/')
+		switch {
+		case idxA < 0 && idxB < 0:
+			return strings.Compare(a, b)
+		case idxA < 0 && idxB >= 0:
+			return -1
+		case idxA >= 0 && idxB < 0:
+			return +1
+		}
+
+		// At this point, both keys have '/'
+		if spanRes := strings.Compare(a[:idxA], b[:idxB]); spanRes != 0 {
+			return spanRes
+		}
+		a, b = a[idxA+1:], b[idxB+1:]
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return +1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,31 @@
+package generator
+
+import "testing"
+
+func TestCompareWithSlash(t *testing.T) {
+	for _, test := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"a", "a", 0},
+		{"a", "b", -1},
+		{"b", "a", +1},
+		{"", "", 0},
+		{"", "a", -1},
+		{"a/b", "a/c", -1},
+		{"ab/c", "a/d", +1},
+		// fewer segments sort first, whatever the segments hold
+		{"/a/z", "/a/b/c", -1},
+		{"/a/b/c", "/a/z", +1},
+		{"a", "a/b", -1},
+		{"a/b", "a", +1},
+		{"x/a", "y", +1},
+		{"/a", "/a/", -1},
+		{"a~", "a/b", -1},
+		{"/a/b", "/a/b", 0},
+	} {
+		if actual := CompareWithSlash(test.a, test.b); actual != test.expected {
+			t.Errorf("CompareWithSlash(%q, %q) = %d, expected %d", test.a, test.b, actual, test.expected)
+		}
+	}
+}
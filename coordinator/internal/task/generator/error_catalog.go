@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
+)
+
+const propertiesKeyOversizedValueSize = "oversizedValueSize"
+
+// serverMaxValueSize is the default 4MiB gRPC message size limit of the Oxia
+// server, which an oversized value must exceed to be rejected.
+const serverMaxValueSize = 4 * 1024 * 1024
+
+var _ Workload = &errorCatalog{}
+
+// errorCatalog deliberately provokes the errors of the proto.ExpectedError
+// catalog and asserts Oxia rejects each operation with the right one. The
+// loaded keys are never modified, so gets and the final verification prove
+// that rejected writes left no trace.
+//
+// SESSION_EXPIRED is not provoked, since the clients renew their sessions
+// transparently and a worker has no way to write with an expired one.
+type errorCatalog struct {
+	name   string
+	logger *slog.Logger
+
+	keySpace        int64
+	keyChooser      KeyChooser
+	values          *ValueGenerator
+	actionGenerator *ActionGenerator
+	oversizedValue  []byte
+
+	sequence int64
+	verified int64
+
+	data *DataTree
+}
+
+func (e *errorCatalog) Name() string {
+	return "error-catalog"
+}
+
+func (e *errorCatalog) Requirements() Requirements {
+	return Requirements{
+		Operations: []string{"put", "delete", "get", "list"},
+		Assertions: []string{"get.records", "put.expectedError", "get.expectedError", "delete.expectedError", "list.expectedError"},
+	}
+}
+
+func (e *errorCatalog) KeyRange() (string, string) {
+	return e.name, e.name + "~"
+}
+
+func (e *errorCatalog) Load() (*proto.Operation, bool) {
+	if e.sequence >= e.keySpace {
+		return nil, false
+	}
+	index := e.sequence
+	e.sequence++
+	e.keyChooser.OnWrite(index)
+	value, digest := e.values.Next()
+	e.data.Put(makeFormatInt64(index), digest)
+	return &proto.Operation{
+		Operation: &proto.Operation_Put{
+			Put: &proto.OperationPut{
+				Key:   makeKey(e.name, index),
+				Value: value,
+			},
+		},
+	}, true
+}
+
+func (e *errorCatalog) Steady() (*proto.Operation, bool) {
+	existingIndex := e.keyChooser.Next()
+	existingKey := makeKey(e.name, existingIndex)
+	// keys past the key space are never written
+	missingKey := makeKey(e.name, e.keySpace+e.keyChooser.Next())
+
+	switch e.actionGenerator.Next() {
+	case OpGet:
+		digest, _ := e.data.Get(makeFormatInt64(existingIndex))
+		return &proto.Operation{
+			Assertion: &proto.Assertion{
+				Records: []*proto.Record{e.values.Record(existingKey, digest)},
+			},
+			Operation: &proto.Operation_Get{
+				Get: &proto.OperationGet{
+					Key:            existingKey,
+					ComparisonType: proto.KeyComparisonType_EQUAL,
+				},
+			},
+		}, true
+	case OpGetMissing:
+		return expectError(proto.ExpectedError_KEY_NOT_FOUND, &proto.Operation{
+			Operation: &proto.Operation_Get{
+				Get: &proto.OperationGet{
+					Key:            missingKey,
+					ComparisonType: proto.KeyComparisonType_EQUAL,
+				},
+			},
+		}), true
+	case OpDeleteMissing:
+		return expectError(proto.ExpectedError_KEY_NOT_FOUND, &proto.Operation{
+			Operation: &proto.Operation_Delete{
+				Delete: &proto.OperationDelete{
+					Key: missingKey,
+				},
+			},
+		}), true
+	case OpConflictCreate:
+		value, _ := e.values.Next()
+		versionId := int64(-1)
+		return expectError(proto.ExpectedError_VERSION_CONFLICT, &proto.Operation{
+			Operation: &proto.Operation_Put{
+				Put: &proto.OperationPut{
+					Key:               existingKey,
+					Value:             value,
+					ExpectedVersionId: &versionId,
+				},
+			},
+		}), true
+	case OpStaleUpdate:
+		value, _ := e.values.Next()
+		versionId := int64(math.MaxInt64)
+		return expectError(proto.ExpectedError_VERSION_CONFLICT, &proto.Operation{
+			Operation: &proto.Operation_Put{
+				Put: &proto.OperationPut{
+					Key:               existingKey,
+					Value:             value,
+					ExpectedVersionId: &versionId,
+				},
+			},
+		}), true
+	case OpInvalidRange:
+		return expectError(proto.ExpectedError_INVALID_RANGE, &proto.Operation{
+			Operation: &proto.Operation_List{
+				List: &proto.OperationList{
+					KeyStart: makeKey(e.name, existingIndex+1),
+					KeyEnd:   existingKey,
+				},
+			},
+		}), true
+	case OpOversizedPut:
+		return expectError(proto.ExpectedError_REQUEST_TOO_LARGE, &proto.Operation{
+			Operation: &proto.Operation_Put{
+				Put: &proto.OperationPut{
+					Key:   existingKey,
+					Value: e.oversizedValue,
+				},
+			},
+		}), true
+	}
+	return nil, false
+}
+
+func (e *errorCatalog) Verify(batchSize int) (*proto.Operation, bool) {
+	if e.verified >= e.keySpace {
+		return nil, false
+	}
+	start := e.verified
+	e.verified += int64(batchSize)
+	return verifyIndexedKeys(e.name, e.data, e.values, start, e.verified, e.keySpace-1), true
+}
+
+func expectError(expectedError proto.ExpectedError, operation *proto.Operation) *proto.Operation {
+	operation.Assertion = &proto.Assertion{
+		ExpectedError: &expectedError,
+	}
+	return operation
+}
+
+func NewErrorCatalog(ctx context.Context, tc *config.TestCaseConfig) (Generator, error) {
+	logger := slog.With("generator", "error-catalog", "name", tc.Name)
+	logger.Info("Starting error catalog generator")
+
+	keySpace := int64(100)
+	oversizedValueSize := 2 * serverMaxValueSize
+	if properties := tc.Properties; properties != nil {
+		if num, exist := properties[propertiesKeyKeySpace]; exist {
+			intVal, err := strconv.ParseInt(num, 10, 64)
+			if err != nil || intVal < 1 {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a positive integer", propertiesKeyKeySpace, num)
+			}
+			keySpace = intVal
+		}
+		if num, exist := properties[propertiesKeyOversizedValueSize]; exist {
+			intVal, err := strconv.Atoi(num)
+			if err != nil || intVal <= serverMaxValueSize {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a number of bytes above the %d bytes server limit",
+					propertiesKeyOversizedValueSize, num, serverMaxValueSize)
+			}
+			oversizedValueSize = intVal
+		}
+	}
+
+	actionGenerator, err := NewActionGeneratorFromProperties(tc.Properties, map[OpType]int{
+		OpGet:            20,
+		OpGetMissing:     15,
+		OpDeleteMissing:  15,
+		OpConflictCreate: 15,
+		OpStaleUpdate:    15,
+		OpInvalidRange:   15,
+		OpOversizedPut:   5,
+	})
+	if err != nil {
+		return nil, err
+	}
+	values, err := NewValueGenerator(tc.Name, tc.Properties, logger)
+	if err != nil {
+		return nil, err
+	}
+	keyChooser, err := NewKeyChooser(keySpace, tc.Properties)
+	if err != nil {
+		return nil, err
+	}
+	e := errorCatalog{
+		name:            tc.Name,
+		logger:          logger,
+		keySpace:        keySpace,
+		keyChooser:      keyChooser,
+		values:          values,
+		actionGenerator: actionGenerator,
+		oversizedValue:  make([]byte, oversizedValueSize),
+		data:            NewDataTree(),
+	}
+	return NewPhasedGenerator(ctx, tc, &e, logger)
+}
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+)
+
+func TestErrorCatalogProperties(t *testing.T) {
+	for _, test := range []struct {
+		properties map[string]string
+		valid      bool
+	}{
+		{properties: nil, valid: true},
+		{properties: map[string]string{"keySpace": "10", "oversizedValueSize": "4194305"}, valid: true},
+		{properties: map[string]string{"keySpace": "0"}},
+		{properties: map[string]string{"keySpace": "many"}},
+		{properties: map[string]string{"oversizedValueSize": "0"}},
+		{properties: map[string]string{"oversizedValueSize": "1024"}},
+		{properties: map[string]string{"oversizedValueSize": "4194304"}},
+		{properties: map[string]string{"oversizedValueSize": "8MiB"}},
+	} {
+		_, err := NewErrorCatalog(context.Background(), &config.TestCaseConfig{Name: "errors", Properties: test.properties})
+		if test.valid && err != nil {
+			t.Errorf("properties %v: unexpected error %v", test.properties, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidProperty) {
+			t.Errorf("properties %v: got error %v, expected ErrInvalidProperty", test.properties, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	propertiesKeyNotificationTimeout    = "notificationTimeout"
+	propertiesKeySequenceUpdatesTimeout = "sequenceUpdatesTimeout"
+	propertiesKeyEphemeralExpiryTimeout = "ephemeralExpiryTimeout"
+	propertiesKeyEventuallyPollInterval = "eventuallyPollInterval"
+)
+
+// newEventually returns the window of an eventual assertion, whose timeout
+// is read from the property key and poll interval from eventuallyPollInterval.
+func newEventually(properties map[string]string, key string, defaultTimeout time.Duration) (*proto.Eventually, error) {
+	timeout, err := parseDuration(properties, key, defaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	pollInterval, err := parseDuration(properties, propertiesKeyEventuallyPollInterval, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Eventually{
+		TimeoutMillis:      timeout.Milliseconds(),
+		PollIntervalMillis: pollInterval.Milliseconds(),
+	}, nil
+}
+
+func parseDuration(properties map[string]string, key string, defaultValue time.Duration) (time.Duration, error) {
+	value, exist := properties[key]
+	if !exist {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, errors.Wrapf(ErrInvalidProperty, "%s %q is not a positive duration", key, value)
+	}
+	return d, nil
+}
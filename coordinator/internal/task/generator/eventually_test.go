@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestNewEventually(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		properties   map[string]string
+		timeout      time.Duration
+		pollInterval time.Duration
+		valid        bool
+	}{
+		{name: "defaults", timeout: time.Minute, pollInterval: time.Second, valid: true},
+		{name: "overrides", properties: map[string]string{propertiesKeyNotificationTimeout: "30s", propertiesKeyEventuallyPollInterval: "100ms"},
+			timeout: 30 * time.Second, pollInterval: 100 * time.Millisecond, valid: true},
+		{name: "malformed timeout", properties: map[string]string{propertiesKeyNotificationTimeout: "30"}},
+		{name: "zero timeout", properties: map[string]string{propertiesKeyNotificationTimeout: "0s"}},
+		{name: "negative poll interval", properties: map[string]string{propertiesKeyEventuallyPollInterval: "-1s"}},
+		{name: "malformed poll interval", properties: map[string]string{propertiesKeyEventuallyPollInterval: "often"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			eventually, err := newEventually(test.properties, propertiesKeyNotificationTimeout, time.Minute)
+			if !test.valid {
+				if !errors.Is(err, ErrInvalidProperty) {
+					t.Fatalf("got error %v, expected ErrInvalidProperty", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eventually.TimeoutMillis != test.timeout.Milliseconds() || eventually.PollIntervalMillis != test.pollInterval.Milliseconds() {
+				t.Fatalf("got window %v, expected %s polled every %s", eventually, test.timeout, test.pollInterval)
+			}
+		})
+	}
+}
@@ -1,6 +1,13 @@
 package generator
 
-import "github.com/oxia-io/okk/coordinator/internal/proto"
+import (
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidProperty is returned by the constructors of the generators for a
+// property they cannot run with.
+var ErrInvalidProperty = errors.New("invalid property")
 
 type Generator interface {
 	Name() string
@@ -14,3 +21,40 @@ type ResponseAwareGenerator interface {
 	Generator
 	OnResponse(*proto.ExecuteResponse)
 }
+
+// ResyncableGenerator is an optional interface for generators that can
+// rebuild their model from the actual contents of the store, so that a
+// testcase can go on after an assertion failure.
+type ResyncableGenerator interface {
+	Generator
+
+	// ResyncRanges splits the range holding every key of the model into
+	// ranges scanned one at a time, so that no response grows with the
+	// whole model.
+	ResyncRanges() []ScanRange
+
+	// Resync replaces the model with the records read from ResyncRanges.
+	Resync(records []*proto.Record)
+}
+
+// ScanRange is a [Start, End) range of keys.
+type ScanRange struct {
+	Start string
+	End   string
+}
+
+// Requirements are the capabilities a generator needs from the worker, named
+// as in proto.DescribeResponse.
+type Requirements struct {
+	Operations []string
+	// Assertions are only needed from the worker in the WORKER assertion mode.
+	Assertions []string
+}
+
+// CapabilityAwareGenerator is an optional interface for generators that
+// declare their Requirements, so that a testcase is rejected when its worker
+// lacks them instead of failing assertions.
+type CapabilityAwareGenerator interface {
+	Generator
+	Requirements() Requirements
+}
@@ -0,0 +1,347 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
+)
+
+const propertiesKeyMaxDepth = "maxDepth"
+
+// maxSegment sorts after every segment produced by randomSegment, since no
+// valid UTF-8 sequence starts with a byte above the one it starts with.
+const maxSegment = "\U0010FFFF"
+
+var unicodeSegments = []string{
+	"\u00e9", "e\u0301", "\u03a9", "\u00df", "\u65e5\u672c\u8a9e", "\ud55c\uad6d\uc5b4",
+	"\U0001F600", "\U0001F600\U0001F600", "\u00a0", "\u200b", "\ufeff", "\u05d0",
+}
+
+var binarySafeSegments = []string{
+	"\x00", "\x00\x00", "\x01", "\x1f", "\x7f", " ", "!", "-", ".", "~", "a\x00", "a\x00b", "\t\n",
+}
+
+var prefixSharingSegments = []string{
+	"a", "aa", "a-", "a.", "a~", "ab", "a b", "A", "0", "00", "9",
+}
+
+var _ Workload = &hierarchicalKeys{}
+
+type hierarchicalKeys struct {
+	name   string
+	logger *slog.Logger
+
+	maxDepth        int
+	keyChooser      KeyChooser
+	values          *ValueGenerator
+	actionGenerator *ActionGenerator
+
+	// universe holds every key the generator writes, sorted with CompareWithSlash.
+	universe []string
+	sequence int
+	verified int
+
+	data *DataTree
+}
+
+func (h *hierarchicalKeys) Name() string {
+	return "hierarchical-keys"
+}
+
+func (h *hierarchicalKeys) Requirements() Requirements {
+	return Requirements{
+		Operations: []string{"put", "delete", "deleteRange", "get", "list", "scan"},
+		Assertions: []string{"get.records", "get.emptyRecords", "list.records", "scan.records"},
+	}
+}
+
+// KeyRange is bounded by a key at the maximum depth, since with slash-aware
+// ordering "<name>~" sorts before any nested key.
+func (h *hierarchicalKeys) KeyRange() (string, string) {
+	return h.name + "/", h.name + strings.Repeat("/"+maxSegment, h.maxDepth)
+}
+
+func (h *hierarchicalKeys) Load() (*proto.Operation, bool) {
+	if h.sequence >= len(h.universe) {
+		return nil, false
+	}
+	index := h.sequence
+	h.sequence++
+	return h.put(index)
+}
+
+func (h *hierarchicalKeys) Steady() (*proto.Operation, bool) {
+	return h.processDataValidation()
+}
+
+// Verify scans the key range in batches of batchSize keys of the universe.
+// The first and last batches extend to the ends of KeyRange.
+func (h *hierarchicalKeys) Verify(batchSize int) (*proto.Operation, bool) {
+	if h.verified >= len(h.universe) {
+		return nil, false
+	}
+	keyStart, keyEnd := h.KeyRange()
+	if h.verified > 0 {
+		keyStart = h.universe[h.verified]
+	}
+	h.verified += batchSize
+	if h.verified < len(h.universe) {
+		keyEnd = h.universe[h.verified]
+	}
+	records := make([]*proto.Record, 0)
+	for _, entry := range h.data.RangeScan(keyStart, keyEnd) {
+		records = append(records, h.values.Record(entry.Key, entry.Value))
+	}
+	return scan(keyStart, keyEnd, records), true
+}
+
+func (h *hierarchicalKeys) processDataValidation() (*proto.Operation, bool) {
+	action := h.actionGenerator.Next()
+	switch action {
+	case OpPut:
+		return h.put(int(h.keyChooser.Next()))
+	case OpDelete:
+		key := h.pickKey()
+		h.data.Delete(key)
+		return &proto.Operation{
+			Operation: &proto.Operation_Delete{
+				Delete: &proto.OperationDelete{
+					Key: key,
+				},
+			},
+		}, true
+	case OpDeleteRange:
+		keyStart, keyEnd := h.pickRange()
+		h.data.DeleteRange(keyStart, keyEnd)
+		return &proto.Operation{
+			Operation: &proto.Operation_DeleteRange{
+				DeleteRange: &proto.OperationDeleteRange{
+					KeyStart: keyStart,
+					KeyEnd:   keyEnd,
+				},
+			},
+		}, true
+	case OpGet:
+		key := h.pickProbeKey()
+		value, found := h.data.Get(key)
+		var entry *Entry
+		if found {
+			entry = &Entry{Key: key, Value: value}
+		}
+		return h.get(key, proto.KeyComparisonType_EQUAL, entry), true
+	case OpGetFloor:
+		key := h.pickProbeKey()
+		entry, _ := h.data.GetFloor(key)
+		return h.get(key, proto.KeyComparisonType_FLOOR, entry), true
+	case OpGetCeiling:
+		key := h.pickProbeKey()
+		entry, _ := h.data.GetCeiling(key)
+		return h.get(key, proto.KeyComparisonType_CEILING, entry), true
+	case OpGetHigher:
+		key := h.pickProbeKey()
+		entry, _ := h.data.GetHigher(key)
+		return h.get(key, proto.KeyComparisonType_HIGHER, entry), true
+	case OpGetLower:
+		key := h.pickProbeKey()
+		entry, _ := h.data.GetLower(key)
+		return h.get(key, proto.KeyComparisonType_LOWER, entry), true
+	case OpList:
+		keyStart, keyEnd := h.pickRange()
+		records := make([]*proto.Record, 0)
+		for _, key := range h.data.List(keyStart, keyEnd) {
+			records = append(records, &proto.Record{
+				Key: key,
+			})
+		}
+		return &proto.Operation{
+			Assertion: &proto.Assertion{
+				Records: records,
+			},
+			Operation: &proto.Operation_List{
+				List: &proto.OperationList{
+					KeyStart: keyStart,
+					KeyEnd:   keyEnd,
+				},
+			},
+		}, true
+	case OpScan:
+		keyStart, keyEnd := h.pickRange()
+		records := make([]*proto.Record, 0)
+		for _, entry := range h.data.RangeScan(keyStart, keyEnd) {
+			records = append(records, h.values.Record(entry.Key, entry.Value))
+		}
+		return &proto.Operation{
+			Assertion: &proto.Assertion{
+				Records: records,
+			},
+			Operation: &proto.Operation_Scan{
+				Scan: &proto.OperationScan{
+					KeyStart: keyStart,
+					KeyEnd:   keyEnd,
+				},
+			},
+		}, true
+	}
+	return nil, false
+}
+
+func (h *hierarchicalKeys) put(index int) (*proto.Operation, bool) {
+	h.keyChooser.OnWrite(int64(index))
+	key := h.universe[index]
+	value, digest := h.values.Next()
+	h.data.Put(key, digest)
+	return &proto.Operation{
+		Operation: &proto.Operation_Put{
+			Put: &proto.OperationPut{
+				Key:   key,
+				Value: value,
+			},
+		},
+	}, true
+}
+
+func (h *hierarchicalKeys) get(key string, comparisonType proto.KeyComparisonType, entry *Entry) *proto.Operation {
+	emptyRecord := entry == nil
+	assertion := &proto.Assertion{
+		EmptyRecords: &emptyRecord,
+	}
+	if entry != nil {
+		assertion.Records = []*proto.Record{h.values.Record(entry.Key, entry.Value)}
+	}
+	return &proto.Operation{
+		Assertion: assertion,
+		Operation: &proto.Operation_Get{
+			Get: &proto.OperationGet{
+				Key:            key,
+				ComparisonType: comparisonType,
+			},
+		},
+	}
+}
+
+func (h *hierarchicalKeys) pickKey() string {
+	return h.universe[h.keyChooser.Next()]
+}
+
+// pickProbeKey returns either a key of the universe or a fresh one, so that
+// floor/ceiling lookups land both on and between the stored keys.
+func (h *hierarchicalKeys) pickProbeKey() string {
+	if rand.IntN(2) == 0 {
+		return h.pickKey()
+	}
+	return h.randomKey()
+}
+
+// pickRange returns an ordered pair of keys. Empty ranges and ranges that
+// span several depths are both intended.
+func (h *hierarchicalKeys) pickRange() (string, string) {
+	keyStart, keyEnd := h.pickProbeKey(), h.pickProbeKey()
+	if CompareWithSlash(keyStart, keyEnd) > 0 {
+		keyStart, keyEnd = keyEnd, keyStart
+	}
+	return keyStart, keyEnd
+}
+
+func (h *hierarchicalKeys) randomKey() string {
+	depth := 1 + rand.IntN(h.maxDepth)
+	var sb strings.Builder
+	sb.WriteString(h.name)
+	for range depth {
+		sb.WriteByte('/')
+		sb.WriteString(randomSegment())
+	}
+	return sb.String()
+}
+
+func randomSegment() string {
+	switch rand.IntN(6) {
+	case 0:
+		return fmt.Sprintf("%04d", rand.IntN(10000))
+	case 1:
+		return ""
+	case 2:
+		return unicodeSegments[rand.IntN(len(unicodeSegments))]
+	case 3:
+		return binarySafeSegments[rand.IntN(len(binarySafeSegments))]
+	case 4:
+		// very long keys
+		return strings.Repeat(string(rune('a'+rand.IntN(26))), 256+rand.IntN(2048))
+	default:
+		return prefixSharingSegments[rand.IntN(len(prefixSharingSegments))]
+	}
+}
+
+func NewHierarchicalKeys(ctx context.Context, tc *config.TestCaseConfig) (Generator, error) {
+	logger := slog.With("generator", "hierarchical-keys", "name", tc.Name)
+
+	keySpace := 200
+	maxDepth := 4
+	if properties := tc.Properties; properties != nil {
+		if num, exist := properties[propertiesKeyKeySpace]; exist {
+			intVal, err := strconv.Atoi(num)
+			if err != nil || intVal < 1 {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a positive integer", propertiesKeyKeySpace, num)
+			}
+			keySpace = intVal
+		}
+		if num, exist := properties[propertiesKeyMaxDepth]; exist {
+			intVal, err := strconv.Atoi(num)
+			if err != nil || intVal < 1 {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a positive integer", propertiesKeyMaxDepth, num)
+			}
+			maxDepth = intVal
+		}
+	}
+
+	logger.Info("Starting hierarchical keys generator", "keySpace", keySpace, "maxDepth", maxDepth)
+
+	actionGenerator, err := NewActionGeneratorFromProperties(tc.Properties, map[OpType]int{
+		OpPut:         25,
+		OpDelete:      10,
+		OpDeleteRange: 5,
+		OpGet:         10,
+		OpGetFloor:    10,
+		OpGetCeiling:  10,
+		OpGetHigher:   10,
+		OpGetLower:    10,
+		OpList:        5,
+		OpScan:        5,
+	})
+	if err != nil {
+		return nil, err
+	}
+	values, err := NewValueGenerator(tc.Name, tc.Properties, logger)
+	if err != nil {
+		return nil, err
+	}
+	hk := &hierarchicalKeys{
+		logger:          logger,
+		actionGenerator: actionGenerator,
+		name:            tc.Name,
+		maxDepth:        maxDepth,
+		values:          values,
+		data:            NewHierarchicalDataTree(),
+	}
+
+	unique := make(map[string]struct{}, keySpace)
+	for len(unique) < keySpace {
+		unique[hk.randomKey()] = struct{}{}
+	}
+	hk.universe = make([]string, 0, keySpace)
+	for key := range unique {
+		hk.universe = append(hk.universe, key)
+	}
+	slices.SortFunc(hk.universe, CompareWithSlash)
+	if hk.keyChooser, err = NewKeyChooser(int64(len(hk.universe)), tc.Properties); err != nil {
+		return nil, err
+	}
+	return NewPhasedGenerator(ctx, tc, hk, logger)
+}
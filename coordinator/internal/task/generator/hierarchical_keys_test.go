@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+)
+
+func TestHierarchicalKeysKeyRange(t *testing.T) {
+	for _, maxDepth := range []int{1, 2, 4, 8} {
+		t.Run(strconv.Itoa(maxDepth), func(t *testing.T) {
+			gen, err := NewHierarchicalKeys(context.Background(), &config.TestCaseConfig{
+				Name:       "hierarchical",
+				Properties: map[string]string{"keySpace": "500", "maxDepth": strconv.Itoa(maxDepth)},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := gen.(*phasedGenerator).workload.(*hierarchicalKeys)
+			keyStart, keyEnd := h.KeyRange()
+			for _, key := range h.universe {
+				if CompareWithSlash(key, keyStart) < 0 || CompareWithSlash(key, keyEnd) >= 0 {
+					t.Fatalf("key %q is out of the key range [%q, %q)", key, keyStart, keyEnd)
+				}
+			}
+		})
+	}
+}
+
+func TestHierarchicalKeysInvalidProperties(t *testing.T) {
+	for _, properties := range []map[string]string{
+		{"keySpace": "0"},
+		{"keySpace": "-10"},
+		{"keySpace": "many"},
+		{"maxDepth": "0"},
+		{"maxDepth": "-1"},
+		{"maxDepth": "deep"},
+	} {
+		_, err := NewHierarchicalKeys(context.Background(), &config.TestCaseConfig{Name: "hierarchical", Properties: properties})
+		if !errors.Is(err, ErrInvalidProperty) {
+			t.Errorf("properties %v: got error %v, expected ErrInvalidProperty", properties, err)
+		}
+	}
+}
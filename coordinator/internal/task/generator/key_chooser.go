@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	propertiesKeyKeyDistribution    = "keyDistribution"
+	propertiesKeyZipfianSkew        = "zipfianSkew"
+	propertiesKeyHotspotOpsPercent  = "hotspotOpsPercent"
+	propertiesKeyHotspotKeysPercent = "hotspotKeysPercent"
+)
+
+// Key distribution names accepted by the keyDistribution property.
+const (
+	KeyDistributionUniform    = "uniform"
+	KeyDistributionZipfian    = "zipfian"
+	KeyDistributionHotspot    = "hotspot"
+	KeyDistributionLatest     = "latest"
+	KeyDistributionSequential = "sequential"
+)
+
+// KeyChooser picks the index, in [0, keySpace), of the key an operation targets.
+type KeyChooser interface {
+	Next() int64
+
+	// OnWrite records that the key at index has been written.
+	OnWrite(index int64)
+}
+
+type uniformKeyChooser struct {
+	keySpace int64
+}
+
+func (u *uniformKeyChooser) Next() int64 {
+	return rand.Int64N(u.keySpace)
+}
+
+func (*uniformKeyChooser) OnWrite(int64) {}
+
+// zipfianKeyChooser favors the lowest indexes, the higher the skew the more.
+type zipfianKeyChooser struct {
+	zipf *rand.Zipf
+}
+
+func (z *zipfianKeyChooser) Next() int64 {
+	return int64(z.zipf.Uint64())
+}
+
+func (*zipfianKeyChooser) OnWrite(int64) {}
+
+// hotspotKeyChooser sends hotOpsPercent of the operations to the first
+// hotKeysPercent of the key space and the rest uniformly to the others.
+type hotspotKeyChooser struct {
+	keySpace      int64
+	hotKeys       int64
+	hotOpsPercent int
+}
+
+func (h *hotspotKeyChooser) Next() int64 {
+	if rand.IntN(100) < h.hotOpsPercent || h.hotKeys >= h.keySpace {
+		return rand.Int64N(h.hotKeys)
+	}
+	return h.hotKeys + rand.Int64N(h.keySpace-h.hotKeys)
+}
+
+func (*hotspotKeyChooser) OnWrite(int64) {}
+
+// latestKeyChooser favors the most recently written keys, following a
+// zipfian distribution over the distance to the latest write.
+type latestKeyChooser struct {
+	keySpace int64
+	zipf     *rand.Zipf
+	latest   int64
+}
+
+func (l *latestKeyChooser) Next() int64 {
+	return ((l.latest-int64(l.zipf.Uint64()))%l.keySpace + l.keySpace) % l.keySpace
+}
+
+func (l *latestKeyChooser) OnWrite(index int64) {
+	l.latest = index
+}
+
+type sequentialKeyChooser struct {
+	keySpace int64
+	next     int64
+}
+
+func (s *sequentialKeyChooser) Next() int64 {
+	index := s.next
+	s.next = (s.next + 1) % s.keySpace
+	return index
+}
+
+func (*sequentialKeyChooser) OnWrite(int64) {}
+
+// NewKeyChooser builds the KeyChooser selected by the keyDistribution
+// property, uniform by default.
+func NewKeyChooser(keySpace int64, properties map[string]string) (KeyChooser, error) {
+	if keySpace < 1 {
+		keySpace = 1
+	}
+	distribution := properties[propertiesKeyKeyDistribution]
+	switch distribution {
+	case "", KeyDistributionUniform:
+		return &uniformKeyChooser{keySpace: keySpace}, nil
+	case KeyDistributionZipfian:
+		zipf, err := newZipf(keySpace, properties)
+		if err != nil {
+			return nil, err
+		}
+		return &zipfianKeyChooser{zipf: zipf}, nil
+	case KeyDistributionHotspot:
+		hotOpsPercent, err := parsePercent(properties, propertiesKeyHotspotOpsPercent, 80)
+		if err != nil {
+			return nil, err
+		}
+		hotKeysPercent, err := parsePercent(properties, propertiesKeyHotspotKeysPercent, 20)
+		if err != nil {
+			return nil, err
+		}
+		return &hotspotKeyChooser{
+			keySpace:      keySpace,
+			hotKeys:       max(1, keySpace*int64(hotKeysPercent)/100),
+			hotOpsPercent: hotOpsPercent,
+		}, nil
+	case KeyDistributionLatest:
+		zipf, err := newZipf(keySpace, properties)
+		if err != nil {
+			return nil, err
+		}
+		return &latestKeyChooser{keySpace: keySpace, zipf: zipf}, nil
+	case KeyDistributionSequential:
+		return &sequentialKeyChooser{keySpace: keySpace}, nil
+	default:
+		return nil, errors.Wrapf(ErrInvalidProperty, "unknown %s %q, expected one of %s", propertiesKeyKeyDistribution, distribution,
+			strings.Join([]string{KeyDistributionUniform, KeyDistributionZipfian, KeyDistributionHotspot, KeyDistributionLatest, KeyDistributionSequential}, ", "))
+	}
+}
+
+func newZipf(keySpace int64, properties map[string]string) (*rand.Zipf, error) {
+	skew := 1.1
+	if value, exist := properties[propertiesKeyZipfianSkew]; exist {
+		floatVal, err := strconv.ParseFloat(value, 64)
+		if err != nil || floatVal <= 1 {
+			return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a number greater than 1", propertiesKeyZipfianSkew, value)
+		}
+		skew = floatVal
+	}
+	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	return rand.NewZipf(r, skew, 1, uint64(keySpace-1)), nil
+}
+
+func parsePercent(properties map[string]string, key string, defaultValue int) (int, error) {
+	value, exist := properties[key]
+	if !exist {
+		return defaultValue, nil
+	}
+	intVal, err := strconv.Atoi(value)
+	if err != nil || intVal < 0 || intVal > 100 {
+		return 0, errors.Wrapf(ErrInvalidProperty, "%s %q is not a percentage between 0 and 100", key, value)
+	}
+	return intVal, nil
+}
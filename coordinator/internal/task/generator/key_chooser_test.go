@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestKeyChooserBounds(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		properties map[string]string
+	}{
+		{"default", nil},
+		{"uniform", map[string]string{propertiesKeyKeyDistribution: KeyDistributionUniform}},
+		{"zipfian", map[string]string{propertiesKeyKeyDistribution: KeyDistributionZipfian}},
+		{"zipfian skewed", map[string]string{propertiesKeyKeyDistribution: KeyDistributionZipfian, propertiesKeyZipfianSkew: "3"}},
+		{"hotspot", map[string]string{propertiesKeyKeyDistribution: KeyDistributionHotspot}},
+		{"hotspot all hot", map[string]string{propertiesKeyKeyDistribution: KeyDistributionHotspot, propertiesKeyHotspotKeysPercent: "100"}},
+		{"hotspot no hot key", map[string]string{propertiesKeyKeyDistribution: KeyDistributionHotspot, propertiesKeyHotspotKeysPercent: "0"}},
+		{"latest", map[string]string{propertiesKeyKeyDistribution: KeyDistributionLatest}},
+		{"sequential", map[string]string{propertiesKeyKeyDistribution: KeyDistributionSequential}},
+	} {
+		for _, keySpace := range []int64{-5, 0, 1, 2, 10, 1000} {
+			chooser, err := NewKeyChooser(keySpace, test.properties)
+			if err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			// the key space is clamped to a single key
+			bound := max(keySpace, 1)
+			for i := range int64(5000) {
+				chooser.OnWrite(i % bound)
+				if index := chooser.Next(); index < 0 || index >= bound {
+					t.Fatalf("%s: index %d is out of the key space %d", test.name, index, keySpace)
+				}
+			}
+		}
+	}
+}
+
+func TestKeyChooserDistributions(t *testing.T) {
+	const keySpace = 100
+	const samples = 20000
+	for _, test := range []struct {
+		name       string
+		properties map[string]string
+		written    int64
+		check      func(t *testing.T, counts []int)
+	}{
+		{
+			name:       "zipfian favors the first key",
+			properties: map[string]string{propertiesKeyKeyDistribution: KeyDistributionZipfian},
+			check: func(t *testing.T, counts []int) {
+				for i := 1; i < keySpace; i++ {
+					if counts[i] > counts[0] {
+						t.Fatalf("key %d picked %d times, more than the first key %d", i, counts[i], counts[0])
+					}
+				}
+			},
+		},
+		{
+			name: "hotspot only picks the hot keys",
+			properties: map[string]string{propertiesKeyKeyDistribution: KeyDistributionHotspot,
+				propertiesKeyHotspotOpsPercent: "100", propertiesKeyHotspotKeysPercent: "10"},
+			check: func(t *testing.T, counts []int) {
+				for i := 10; i < keySpace; i++ {
+					if counts[i] > 0 {
+						t.Fatalf("cold key %d picked %d times", i, counts[i])
+					}
+				}
+			},
+		},
+		{
+			name: "hotspot never picks the hot keys",
+			properties: map[string]string{propertiesKeyKeyDistribution: KeyDistributionHotspot,
+				propertiesKeyHotspotOpsPercent: "0", propertiesKeyHotspotKeysPercent: "10"},
+			check: func(t *testing.T, counts []int) {
+				for i := range 10 {
+					if counts[i] > 0 {
+						t.Fatalf("hot key %d picked %d times", i, counts[i])
+					}
+				}
+			},
+		},
+		{
+			name:       "latest favors the last written key",
+			properties: map[string]string{propertiesKeyKeyDistribution: KeyDistributionLatest},
+			written:    42,
+			check: func(t *testing.T, counts []int) {
+				for i := range keySpace {
+					if counts[i] > counts[42] {
+						t.Fatalf("key %d picked %d times, more than the last written key %d", i, counts[i], counts[42])
+					}
+				}
+			},
+		},
+		{
+			name:       "sequential picks every key in turn",
+			properties: map[string]string{propertiesKeyKeyDistribution: KeyDistributionSequential},
+			check: func(t *testing.T, counts []int) {
+				for i := range keySpace {
+					if counts[i] != samples/keySpace {
+						t.Fatalf("key %d picked %d times, expected %d", i, counts[i], samples/keySpace)
+					}
+				}
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			chooser, err := NewKeyChooser(keySpace, test.properties)
+			if err != nil {
+				t.Fatal(err)
+			}
+			chooser.OnWrite(test.written)
+			counts := make([]int, keySpace)
+			for range samples {
+				counts[chooser.Next()]++
+			}
+			test.check(t, counts)
+		})
+	}
+}
+
+func TestSequentialKeyChooserWraps(t *testing.T) {
+	chooser, err := NewKeyChooser(3, map[string]string{propertiesKeyKeyDistribution: KeyDistributionSequential})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []int64{0, 1, 2, 0, 1, 2, 0} {
+		if index := chooser.Next(); index != expected {
+			t.Fatalf("pick %d is %d, expected %d", i, index, expected)
+		}
+	}
+}
+
+func TestKeyChooserInvalidProperties(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		properties map[string]string
+	}{
+		{"unknown distribution", map[string]string{propertiesKeyKeyDistribution: "gaussian"}},
+		{"zipfian skew too low", map[string]string{propertiesKeyKeyDistribution: KeyDistributionZipfian, propertiesKeyZipfianSkew: "0.5"}},
+		{"zipfian skew malformed", map[string]string{propertiesKeyKeyDistribution: KeyDistributionZipfian, propertiesKeyZipfianSkew: "x"}},
+		{"latest skew too low", map[string]string{propertiesKeyKeyDistribution: KeyDistributionLatest, propertiesKeyZipfianSkew: "1"}},
+		{"hotspot ops percent above 100", map[string]string{propertiesKeyKeyDistribution: KeyDistributionHotspot, propertiesKeyHotspotOpsPercent: "101"}},
+		{"hotspot keys percent negative", map[string]string{propertiesKeyKeyDistribution: KeyDistributionHotspot, propertiesKeyHotspotKeysPercent: "-1"}},
+		{"hotspot keys percent malformed", map[string]string{propertiesKeyKeyDistribution: KeyDistributionHotspot, propertiesKeyHotspotKeysPercent: "20%"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewKeyChooser(100, test.properties); !errors.Is(err, ErrInvalidProperty) {
+				t.Fatalf("got error %v, expected ErrInvalidProperty", err)
+			}
+		})
+	}
+}
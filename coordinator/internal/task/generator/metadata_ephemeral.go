@@ -15,7 +15,7 @@ import (
 
 const propertiesKeyCheckpointNum = "checkpointNum"
 
-var _ Generator = &metadataEphemeral{}
+var _ CapabilityAwareGenerator = &metadataEphemeral{}
 
 type metadataEphemeral struct {
 	ctx      context.Context
@@ -28,10 +28,11 @@ type metadataEphemeral struct {
 	rateLimit     *rate.Limiter
 	startTime     time.Time
 
-	sequence       int64
 	counter        uint
 	checkPoint     uint
 	checkEphemeral bool
+	// expiryWindow is how long the ephemeral records may outlive their session.
+	expiryWindow *proto.Eventually
 }
 
 func (m *metadataEphemeral) Next() (*proto.Operation, bool) {
@@ -46,7 +47,6 @@ func (m *metadataEphemeral) Next() (*proto.Operation, bool) {
 	if !m.checkEphemeral && !m.maybeResetCounter() {
 		operation := &proto.Operation{
 			Timestamp: time.Now().UnixNano(),
-			Sequence:  m.nextSequence(),
 			Operation: &proto.Operation_Put{
 				Put: &proto.OperationPut{
 					Key:       fmt.Sprintf("/ephemeral/%s/%d", m.taskName, m.counter),
@@ -60,7 +60,6 @@ func (m *metadataEphemeral) Next() (*proto.Operation, bool) {
 	if !m.checkEphemeral {
 		operation = &proto.Operation{
 			Timestamp: time.Now().UnixNano(),
-			Sequence:  m.nextSequence(),
 			Operation: &proto.Operation_SessionRestart{
 				SessionRestart: &proto.OperationSessionRestart{},
 			},
@@ -71,9 +70,9 @@ func (m *metadataEphemeral) Next() (*proto.Operation, bool) {
 	assertEmpty := true
 	operation = &proto.Operation{
 		Timestamp: time.Now().UnixNano(),
-		Sequence:  m.nextSequence(),
 		Assertion: &proto.Assertion{
-			EventuallyEmpty: &assertEmpty,
+			EmptyRecords: &assertEmpty,
+			Eventually:   m.expiryWindow,
 		},
 		Operation: &proto.Operation_List{
 			List: &proto.OperationList{
@@ -90,10 +89,11 @@ func (m *metadataEphemeral) Name() string {
 	return "metadata-ephemeral"
 }
 
-func (m *metadataEphemeral) nextSequence() int64 {
-	nextSequence := m.sequence
-	m.sequence = m.sequence + 1
-	return nextSequence
+func (m *metadataEphemeral) Requirements() Requirements {
+	return Requirements{
+		Operations: []string{"put", "list", "sessionRestart"},
+		Assertions: []string{"list.emptyRecords"},
+	}
 }
 
 func (m *metadataEphemeral) maybeResetCounter() bool {
@@ -106,9 +106,11 @@ func (m *metadataEphemeral) maybeResetCounter() bool {
 	return true
 }
 
-func NewMetadataEphemeralGenerator(ctx context.Context, tc *config.TestCaseConfig) Generator {
-	currentContext, currentContextCanceled := context.WithCancel(ctx)
+func NewMetadataEphemeralGenerator(ctx context.Context, tc *config.TestCaseConfig) (Generator, error) {
 	logger := slog.With("generator", "metadata-ephemeral", "name", tc.Name)
+	if err := rejectOpWeights(tc.Properties); err != nil {
+		return nil, err
+	}
 
 	checkpointNum := uint(1000)
 	if properties := tc.Properties; properties != nil {
@@ -124,7 +126,13 @@ func NewMetadataEphemeralGenerator(ctx context.Context, tc *config.TestCaseConfi
 
 	logger.Info("Starting metadata ephemeral generator", "checkpointNum", checkpointNum)
 
+	expiryWindow, err := newEventually(tc.Properties, propertiesKeyEphemeralExpiryTimeout, 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
 	opRate := tc.GetOpRate()
+	currentContext, currentContextCanceled := context.WithCancel(ctx)
 	me := metadataEphemeral{
 		logger:        logger,
 		ctx:           currentContext,
@@ -133,9 +141,9 @@ func NewMetadataEphemeralGenerator(ctx context.Context, tc *config.TestCaseConfi
 		checkpointNum: checkpointNum,
 		duration:      tc.GetDuration(),
 		startTime:     time.Now(),
-		sequence:      0,
 		rateLimit:     rate.NewLimiter(rate.Limit(opRate), opRate),
+		expiryWindow:  expiryWindow,
 	}
 	me.maybeResetCounter()
-	return &me
+	return &me, nil
 }
@@ -13,10 +13,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/oxia-io/okk/coordinator/internal/config"
 	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
 	"golang.org/x/time/rate"
 )
 
-var _ Generator = &metadataNotification{}
+var _ CapabilityAwareGenerator = &metadataNotification{}
 
 type metadataNotification struct {
 	ctx      context.Context
@@ -31,8 +32,11 @@ type metadataNotification struct {
 	sequence uint
 
 	keySpace        uint
+	keyChooser      KeyChooser
 	initialized     bool
 	actionGenerator *ActionGenerator
+	// notificationWindow is how long a notification may take to be received.
+	notificationWindow *proto.Eventually
 
 	keys bitset.BitSet
 }
@@ -41,6 +45,13 @@ func (m *metadataNotification) Name() string {
 	return "metadata-notification"
 }
 
+func (m *metadataNotification) Requirements() Requirements {
+	return Requirements{
+		Operations: []string{"put", "delete", "deleteRange"},
+		Assertions: []string{"put.notification", "delete.notification", "deleteRange.notification"},
+	}
+}
+
 func (m *metadataNotification) nextSequence() uint {
 	nextSequence := m.sequence
 	m.sequence = m.sequence + 1
@@ -66,7 +77,7 @@ func (m *metadataNotification) Next() (*proto.Operation, bool) {
 
 func (m *metadataNotification) processDataValidation() (*proto.Operation, bool) {
 	action := m.actionGenerator.Next()
-	keyIndex := rand.UintN(m.keySpace)
+	keyIndex := uint(m.keyChooser.Next())
 	watchNotification := true
 	switch action {
 	case OpPut:
@@ -81,6 +92,7 @@ func (m *metadataNotification) processDataValidation() (*proto.Operation, bool)
 				notificationType = proto.NotificationType_KEY_CREATED
 			}
 			m.keys.Set(keyIndex)
+			m.keyChooser.OnWrite(int64(keyIndex))
 
 			return &proto.Operation{
 				Precondition: &proto.Precondition{
@@ -91,6 +103,7 @@ func (m *metadataNotification) processDataValidation() (*proto.Operation, bool)
 						Type: notificationType,
 						Key:  &key,
 					},
+					Eventually: m.notificationWindow,
 				},
 				Operation: &proto.Operation_Put{
 					Put: &proto.OperationPut{
@@ -120,6 +133,7 @@ func (m *metadataNotification) processDataValidation() (*proto.Operation, bool)
 						Type: proto.NotificationType_KEY_DELETED,
 						Key:  &key,
 					},
+					Eventually: m.notificationWindow,
 				}
 			}
 			m.keys.Clear(keyIndex)
@@ -146,6 +160,7 @@ func (m *metadataNotification) processDataValidation() (*proto.Operation, bool)
 						KeyStart: &keyStart,
 						KeyEnd:   &keyEnd,
 					},
+					Eventually: m.notificationWindow,
 				},
 				Operation: &proto.Operation_DeleteRange{
 					DeleteRange: &proto.OperationDeleteRange{
@@ -184,12 +199,12 @@ func (m *metadataNotification) processInitStage() (*proto.Operation, bool) {
 				Type: proto.NotificationType_KEY_CREATED,
 				Key:  &key,
 			},
+			Eventually: m.notificationWindow,
 		},
 	}, true
 }
 
-func NewMetadataNotificationGenerator(ctx context.Context, tc *config.TestCaseConfig) Generator {
-	currentContext, currentContextCanceled := context.WithCancel(ctx)
+func NewMetadataNotificationGenerator(ctx context.Context, tc *config.TestCaseConfig) (Generator, error) {
 	logger := slog.With("generator", "metadata-notification", "name", tc.Name)
 	logger.Info("Starting metadata notification generator")
 
@@ -210,12 +225,35 @@ func NewMetadataNotificationGenerator(ctx context.Context, tc *config.TestCaseCo
 	}
 
 	opRate := tc.GetOpRate()
-	actionGenerator := NewActionGenerator(map[OpType]int{
+	actionGenerator, err := NewActionGeneratorFromProperties(tc.Properties, map[OpType]int{
 		OpPut:         34,
 		OpDelete:      33,
 		OpDeleteRange: 33,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	keyChooser, err := NewKeyChooser(int64(keySpace), tc.Properties)
+	if err != nil {
+		return nil, err
+	}
 
+	// the worker waits for the notification of a write before responding, so
+	// the coordinator must not time the write out first
+	notificationWindow, err := newEventually(tc.Properties, propertiesKeyNotificationTimeout, 3*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	notificationTimeout := time.Duration(notificationWindow.TimeoutMillis) * time.Millisecond
+	for _, opType := range []string{"put", "delete", "deleteRange"} {
+		if opTimeout := tc.GetOpTimeout(opType); notificationTimeout >= opTimeout {
+			return nil, errors.Wrapf(ErrInvalidProperty, "%s %s must be shorter than the %s timeout %s",
+				propertiesKeyNotificationTimeout, notificationTimeout, opType, opTimeout)
+		}
+	}
+
+	currentContext, currentContextCanceled := context.WithCancel(ctx)
 	return &metadataNotification{
 		logger:          logger,
 		ctx:             currentContext,
@@ -227,6 +265,9 @@ func NewMetadataNotificationGenerator(ctx context.Context, tc *config.TestCaseCo
 		actionGenerator: actionGenerator,
 		initialized:     false,
 		keySpace:        keySpace,
+		keyChooser:      keyChooser,
 		keys:            bitset.BitSet{},
-	}
+
+		notificationWindow: notificationWindow,
+	}, nil
 }
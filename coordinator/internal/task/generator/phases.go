@@ -0,0 +1,320 @@
+package generator
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	propertiesKeyVerify          = "verify"
+	propertiesKeyVerifyBatchSize = "verifyBatchSize"
+	propertiesKeyTeardown        = "teardown"
+)
+
+// Phase is the stage a phased testcase is in. Phases run in the order they
+// are declared.
+type Phase string
+
+const (
+	// PhaseLoad deletes the data left by a previous run and preloads the key
+	// space, unless the testcase duration elapses first.
+	PhaseLoad Phase = "load"
+	// PhaseSteady runs the operation mix until the testcase duration elapses.
+	PhaseSteady Phase = "steady"
+	// PhaseVerify scans the whole key space and diffs it against the model.
+	PhaseVerify Phase = "verify"
+	// PhaseTeardown deletes the key space, when the teardown property is set.
+	PhaseTeardown Phase = "teardown"
+	PhaseDone     Phase = "done"
+)
+
+// Workload is a testcase whose operations are split into phases. The
+// phasedGenerator wrapping it owns the rate limit, the duration and the
+// phase transitions, so a Workload only produces operations and keeps its
+// model up to date.
+type Workload interface {
+	Name() string
+
+	// KeyRange returns the [start, end) range holding every key the workload
+	// writes, which is deleted before the load phase and on teardown.
+	KeyRange() (string, string)
+
+	// Load returns the next preload operation, or false once the key space is loaded.
+	Load() (*proto.Operation, bool)
+
+	// Steady returns the next steady-state operation, or false to end the phase early.
+	Steady() (*proto.Operation, bool)
+
+	// Verify returns the next operation of the final verification, each one
+	// asserting at most batchSize records, or false once the whole key space
+	// has been compared against the model.
+	Verify(batchSize int) (*proto.Operation, bool)
+
+	// Requirements returns the capabilities the Load, Steady and Verify
+	// operations need from the worker.
+	Requirements() Requirements
+}
+
+// ResyncableWorkload is a Workload whose model can be rebuilt, see ResyncableGenerator.
+type ResyncableWorkload interface {
+	Workload
+
+	// ResyncRanges splits KeyRange into ranges of at most batchSize keys.
+	ResyncRanges(batchSize int) []ScanRange
+
+	// Resync replaces the model with the records read from ResyncRanges.
+	Resync(records []*proto.Record)
+}
+
+// PhasedGenerator is implemented by generators that report their current phase.
+type PhasedGenerator interface {
+	Generator
+	Phase() Phase
+}
+
+var _ PhasedGenerator = &phasedGenerator{}
+var _ ResponseAwareGenerator = &phasedGenerator{}
+var _ CapabilityAwareGenerator = &phasedGenerator{}
+
+type phasedGenerator struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	logger   *slog.Logger
+	workload Workload
+
+	// duration bounds the load and steady phases together, from startTime
+	duration  *time.Duration
+	startTime time.Time
+	rateLimit *rate.Limiter
+
+	phase        Phase
+	phaseStart   time.Time
+	needsCleanup bool
+
+	verify          bool
+	verifyBatchSize int
+	teardown        bool
+}
+
+func (p *phasedGenerator) Name() string {
+	return p.workload.Name()
+}
+
+func (p *phasedGenerator) Phase() Phase {
+	return p.phase
+}
+
+// Requirements adds the delete ranges of the cleanup and teardown, and the
+// scans of the verification, to the requirements of the workload.
+func (p *phasedGenerator) Requirements() Requirements {
+	requirements := p.workload.Requirements()
+	requirements.Operations = appendMissing(requirements.Operations, "deleteRange")
+	if p.verify {
+		requirements.Operations = appendMissing(requirements.Operations, "scan")
+		requirements.Assertions = appendMissing(requirements.Assertions, "scan.records")
+	}
+	return requirements
+}
+
+func appendMissing(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(slices.Clone(values), value)
+}
+
+func (p *phasedGenerator) OnResponse(response *proto.ExecuteResponse) {
+	if rag, ok := p.workload.(interface {
+		OnResponse(*proto.ExecuteResponse)
+	}); ok {
+		rag.OnResponse(response)
+	}
+}
+
+var _ ResyncableGenerator = &resyncablePhasedGenerator{}
+
+type resyncablePhasedGenerator struct {
+	*phasedGenerator
+	workload ResyncableWorkload
+}
+
+// ResyncRanges are as large as the batches of the verification phase.
+func (r *resyncablePhasedGenerator) ResyncRanges() []ScanRange {
+	return r.workload.ResyncRanges(r.verifyBatchSize)
+}
+
+func (r *resyncablePhasedGenerator) Resync(records []*proto.Record) {
+	r.logger.Info("Resynchronizing the model", "records", len(records))
+	r.workload.Resync(records)
+}
+
+func (p *phasedGenerator) Next() (*proto.Operation, bool) {
+	if p.needsCleanup {
+		p.needsCleanup = false
+		keyStart, keyEnd := p.workload.KeyRange()
+		p.logger.Info("Cleaning up stale data from previous run", "keyStart", keyStart, "keyEnd", keyEnd)
+		return deleteRange(keyStart, keyEnd), true
+	}
+
+	for {
+		switch p.phase {
+		case PhaseLoad:
+			if p.expired() {
+				p.enter(PhaseVerify)
+				continue
+			}
+			if !p.wait() {
+				return nil, false
+			}
+			if operation, hasNext := p.workload.Load(); hasNext {
+				return operation, true
+			}
+			p.enter(PhaseSteady)
+		case PhaseSteady:
+			if p.expired() {
+				p.enter(PhaseVerify)
+				continue
+			}
+			if !p.wait() {
+				return nil, false
+			}
+			if operation, hasNext := p.workload.Steady(); hasNext {
+				return operation, true
+			}
+			p.enter(PhaseVerify)
+		case PhaseVerify:
+			if !p.verify {
+				p.enter(PhaseTeardown)
+				continue
+			}
+			if !p.wait() {
+				return nil, false
+			}
+			if operation, hasNext := p.workload.Verify(p.verifyBatchSize); hasNext {
+				return operation, true
+			}
+			p.logger.Info("Full verification passed")
+			p.enter(PhaseTeardown)
+		case PhaseTeardown:
+			p.enter(PhaseDone)
+			if p.teardown {
+				keyStart, keyEnd := p.workload.KeyRange()
+				return deleteRange(keyStart, keyEnd), true
+			}
+		default:
+			return nil, false
+		}
+	}
+}
+
+// expired tells whether the testcase duration elapsed, which ends the load
+// and steady phases. The verify and teardown phases run after it.
+func (p *phasedGenerator) expired() bool {
+	return p.duration != nil && time.Since(p.startTime) > *p.duration
+}
+
+func (p *phasedGenerator) wait() bool {
+	if err := p.rateLimit.Wait(p.ctx); err != nil {
+		p.logger.Error("Failed to wait for rate limiter", "error", err)
+		return false
+	}
+	return true
+}
+
+func (p *phasedGenerator) enter(phase Phase) {
+	p.logger.Info("Entering phase", "phase", phase, "previous", p.phase, "elapsed", time.Since(p.phaseStart))
+	p.phase = phase
+	p.phaseStart = time.Now()
+}
+
+func deleteRange(keyStart string, keyEnd string) *proto.Operation {
+	return &proto.Operation{
+		Operation: &proto.Operation_DeleteRange{
+			DeleteRange: &proto.OperationDeleteRange{
+				KeyStart: keyStart,
+				KeyEnd:   keyEnd,
+			},
+		},
+	}
+}
+
+// scan returns a scan of [keyStart, keyEnd) asserting it holds exactly records.
+func scan(keyStart string, keyEnd string, records []*proto.Record) *proto.Operation {
+	if records == nil {
+		records = make([]*proto.Record, 0)
+	}
+	return &proto.Operation{
+		Assertion: &proto.Assertion{
+			Records: records,
+		},
+		Operation: &proto.Operation_Scan{
+			Scan: &proto.OperationScan{
+				KeyStart: keyStart,
+				KeyEnd:   keyEnd,
+			},
+		},
+	}
+}
+
+// NewPhasedGenerator wraps workload into a Generator running its phases. The
+// load and steady phases last for the testcase duration in total, measured
+// from now, or forever without one. The Generator is a ResyncableGenerator
+// when workload is a ResyncableWorkload.
+func NewPhasedGenerator(ctx context.Context, tc *config.TestCaseConfig, workload Workload, logger *slog.Logger) (Generator, error) {
+	verify := true
+	verifyBatchSize := 100
+	teardown := false
+	if properties := tc.Properties; properties != nil {
+		if value, exist := properties[propertiesKeyVerify]; exist {
+			boolVal, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a boolean", propertiesKeyVerify, value)
+			}
+			verify = boolVal
+		}
+		if num, exist := properties[propertiesKeyVerifyBatchSize]; exist {
+			intVal, err := strconv.Atoi(num)
+			if err != nil || intVal < 1 {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a positive integer", propertiesKeyVerifyBatchSize, num)
+			}
+			verifyBatchSize = intVal
+		}
+		if value, exist := properties[propertiesKeyTeardown]; exist {
+			boolVal, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a boolean", propertiesKeyTeardown, value)
+			}
+			teardown = boolVal
+		}
+	}
+
+	opRate := tc.GetOpRate()
+	currentContext, currentContextCanceled := context.WithCancel(ctx)
+	p := &phasedGenerator{
+		ctx:             currentContext,
+		cancel:          currentContextCanceled,
+		logger:          logger,
+		workload:        workload,
+		duration:        tc.GetDuration(),
+		startTime:       time.Now(),
+		rateLimit:       rate.NewLimiter(rate.Limit(opRate), opRate),
+		phase:           PhaseLoad,
+		phaseStart:      time.Now(),
+		needsCleanup:    true,
+		verify:          verify,
+		verifyBatchSize: verifyBatchSize,
+		teardown:        teardown,
+	}
+	if rw, ok := workload.(ResyncableWorkload); ok {
+		return &resyncablePhasedGenerator{phasedGenerator: p, workload: rw}, nil
+	}
+	return p, nil
+}
@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
+)
+
+func TestPhasedGeneratorProperties(t *testing.T) {
+	for _, test := range []struct {
+		properties map[string]string
+		valid      bool
+	}{
+		{properties: nil, valid: true},
+		{properties: map[string]string{"verify": "false", "verifyBatchSize": "10", "teardown": "true"}, valid: true},
+		{properties: map[string]string{"verify": "flase"}},
+		{properties: map[string]string{"teardown": "ture"}},
+		{properties: map[string]string{"verifyBatchSize": "0"}},
+		{properties: map[string]string{"verifyBatchSize": "ten"}},
+	} {
+		_, err := NewBasicKv(context.Background(), &config.TestCaseConfig{Name: "phased", Properties: test.properties})
+		if test.valid && err != nil {
+			t.Errorf("properties %v: unexpected error %v", test.properties, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidProperty) {
+			t.Errorf("properties %v: got error %v, expected ErrInvalidProperty", test.properties, err)
+		}
+	}
+}
+
+// timedWorkload loads loadOps keys, each taking loadDelay, then runs
+// steady operations forever.
+type timedWorkload struct {
+	loadOps   int
+	loadDelay time.Duration
+	loaded    int
+	steady    int
+	verified  bool
+}
+
+func (*timedWorkload) Name() string { return "timed" }
+
+func (*timedWorkload) KeyRange() (string, string) { return "timed", "timed~" }
+
+func (*timedWorkload) Requirements() Requirements { return Requirements{} }
+
+func (w *timedWorkload) Load() (*proto.Operation, bool) {
+	if w.loaded >= w.loadOps {
+		return nil, false
+	}
+	time.Sleep(w.loadDelay)
+	w.loaded++
+	return &proto.Operation{}, true
+}
+
+func (w *timedWorkload) Steady() (*proto.Operation, bool) {
+	w.steady++
+	return &proto.Operation{}, true
+}
+
+func (w *timedWorkload) Verify(int) (*proto.Operation, bool) {
+	if w.verified {
+		return nil, false
+	}
+	w.verified = true
+	return &proto.Operation{}, true
+}
+
+func TestPhasedGeneratorDuration(t *testing.T) {
+	const duration = 200 * time.Millisecond
+	for _, test := range []struct {
+		name     string
+		workload *timedWorkload
+		// loaded tells whether the load phase completes within the duration
+		loaded bool
+	}{
+		{name: "short load", workload: &timedWorkload{loadOps: 5}, loaded: true},
+		{name: "load longer than the duration", workload: &timedWorkload{loadOps: 1000, loadDelay: 10 * time.Millisecond}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now()
+			gen, err := NewPhasedGenerator(context.Background(), &config.TestCaseConfig{
+				Name:     "timed",
+				OpRate:   1_000_000,
+				Duration: duration.String(),
+			}, test.workload, slog.Default())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for {
+				if _, hasNext := gen.Next(); !hasNext {
+					break
+				}
+			}
+			elapsed := time.Since(start)
+
+			// the duration bounds the whole run, the load phase included
+			if elapsed < duration || elapsed > duration+time.Second {
+				t.Fatalf("the run took %s, expected about %s", elapsed, duration)
+			}
+			if loaded := test.workload.loaded == test.workload.loadOps; loaded != test.loaded {
+				t.Fatalf("loaded %d of %d keys", test.workload.loaded, test.workload.loadOps)
+			}
+			if (test.workload.steady > 0) != test.loaded {
+				t.Fatalf("ran %d steady operations", test.workload.steady)
+			}
+			if !test.workload.verified {
+				t.Fatal("the verify phase did not run after the duration")
+			}
+			if phase := gen.(PhasedGenerator).Phase(); phase != PhaseDone {
+				t.Fatalf("ended in phase %s", phase)
+			}
+		})
+	}
+}
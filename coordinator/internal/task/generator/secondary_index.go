@@ -0,0 +1,286 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
+)
+
+const propertiesKeyIndexSpace = "indexSpace"
+
+const secondaryIndexName = "okk-bucket"
+
+var _ Workload = &secondaryIndex{}
+
+type secondaryIndex struct {
+	name   string
+	logger *slog.Logger
+
+	keySpace        int64
+	indexSpace      int64
+	keyChooser      KeyChooser
+	values          *ValueGenerator
+	actionGenerator *ActionGenerator
+
+	sequence         int64
+	verified         int64
+	verifiedIndexKey int64
+
+	// data maps the formatted primary index to the value digest.
+	data *DataTree
+	// secondaryKeys maps the formatted primary index to its current secondary key.
+	secondaryKeys map[string]string
+	// index maps "<secondary key>/<formatted primary index>" to the formatted
+	// primary index, so a range over it yields primaries in Oxia's index order.
+	index *DataTree
+}
+
+func (s *secondaryIndex) Name() string {
+	return "secondary-index"
+}
+
+func (s *secondaryIndex) Requirements() Requirements {
+	return Requirements{
+		// a worker may support an operation without its index options
+		Operations: []string{"put", "delete", "deleteRange", "get", "list",
+			"put.secondaryIndexes", "get.useIndex", "list.useIndex"},
+		Assertions: []string{"get.records", "get.emptyRecords", "list.records"},
+	}
+}
+
+func (s *secondaryIndex) KeyRange() (string, string) {
+	return s.name, s.name + "~"
+}
+
+func (s *secondaryIndex) Load() (*proto.Operation, bool) {
+	if s.sequence >= s.keySpace {
+		return nil, false
+	}
+	return s.put(s.nextSequence())
+}
+
+func (s *secondaryIndex) Steady() (*proto.Operation, bool) {
+	return s.processDataValidation()
+}
+
+// Verify scans the primary keys first and then lists the whole secondary
+// index, batchSize secondary keys at a time.
+func (s *secondaryIndex) Verify(batchSize int) (*proto.Operation, bool) {
+	if s.verified < s.keySpace {
+		start := s.verified
+		s.verified += int64(batchSize)
+		return verifyIndexedKeys(s.name, s.data, s.values, start, s.verified, s.keySpace-1), true
+	}
+	if s.verifiedIndexKey < s.indexSpace {
+		start := s.verifiedIndexKey
+		s.verifiedIndexKey = min(s.indexSpace, start+int64(batchSize))
+		return s.list(start, s.verifiedIndexKey)
+	}
+	return nil, false
+}
+
+func (s *secondaryIndex) processDataValidation() (*proto.Operation, bool) {
+	action := s.actionGenerator.Next()
+	keyIndex := s.keyChooser.Next()
+	switch action {
+	case OpPut:
+		return s.put(keyIndex)
+	case OpDelete:
+		s.unindex(makeFormatInt64(keyIndex))
+		s.data.Delete(makeFormatInt64(keyIndex))
+		return &proto.Operation{
+			Operation: &proto.Operation_Delete{
+				Delete: &proto.OperationDelete{
+					Key: makeKey(s.name, keyIndex),
+				},
+			},
+		}, true
+	case OpDeleteRange:
+		keyStart := keyIndex
+		keyEnd := keyIndex + rand.Int64N(100)
+		for _, primary := range s.data.List(makeFormatInt64(keyStart), makeFormatInt64(keyEnd)) {
+			s.unindex(primary)
+		}
+		s.data.DeleteRange(makeFormatInt64(keyStart), makeFormatInt64(keyEnd))
+		return &proto.Operation{
+			Operation: &proto.Operation_DeleteRange{
+				DeleteRange: &proto.OperationDeleteRange{
+					KeyStart: makeKey(s.name, keyStart),
+					KeyEnd:   makeKey(s.name, keyEnd),
+				},
+			},
+		}, true
+	case OpGet:
+		secondaryStart := rand.Int64N(s.indexSpace)
+		primaries := s.lookup(secondaryStart, secondaryStart+1)
+		if len(primaries) > 1 {
+			// Oxia does not define which primary wins for a duplicated
+			// secondary key, so only the list form can be asserted.
+			return s.list(secondaryStart, secondaryStart+1)
+		}
+		emptyRecord := len(primaries) == 0
+		assertion := &proto.Assertion{
+			EmptyRecords: &emptyRecord,
+		}
+		if !emptyRecord {
+			digest, _ := s.data.Get(primaries[0])
+			assertion.Records = []*proto.Record{s.values.Record(makeKeyWithFormattedIndex(s.name, primaries[0]), digest)}
+		}
+		indexName := secondaryIndexName
+		return &proto.Operation{
+			Assertion: assertion,
+			Operation: &proto.Operation_Get{
+				Get: &proto.OperationGet{
+					Key:            s.makeSecondaryKey(secondaryStart),
+					ComparisonType: proto.KeyComparisonType_EQUAL,
+					UseIndex:       &indexName,
+				},
+			},
+		}, true
+	case OpList:
+		secondaryStart := rand.Int64N(s.indexSpace)
+		return s.list(secondaryStart, secondaryStart+rand.Int64N(10)+1)
+	}
+	return nil, false
+}
+
+func (s *secondaryIndex) put(keyIndex int64) (*proto.Operation, bool) {
+	primary := makeFormatInt64(keyIndex)
+	value, digest := s.values.Next()
+	secondaryKey := s.makeSecondaryKey(rand.Int64N(s.indexSpace))
+	s.keyChooser.OnWrite(keyIndex)
+
+	s.unindex(primary)
+	s.data.Put(primary, digest)
+	s.secondaryKeys[primary] = secondaryKey
+	s.index.Put(secondaryKey+"/"+primary, primary)
+
+	return &proto.Operation{
+		Operation: &proto.Operation_Put{
+			Put: &proto.OperationPut{
+				Key:   makeKey(s.name, keyIndex),
+				Value: value,
+				SecondaryIndexes: []*proto.SecondaryIndex{
+					{
+						IndexName:    secondaryIndexName,
+						SecondaryKey: secondaryKey,
+					},
+				},
+			},
+		},
+	}, true
+}
+
+func (s *secondaryIndex) list(secondaryStart int64, secondaryEnd int64) (*proto.Operation, bool) {
+	records := make([]*proto.Record, 0)
+	for _, primary := range s.lookup(secondaryStart, secondaryEnd) {
+		records = append(records, &proto.Record{
+			Key: makeKeyWithFormattedIndex(s.name, primary),
+		})
+	}
+	indexName := secondaryIndexName
+	return &proto.Operation{
+		Assertion: &proto.Assertion{
+			Records: records,
+		},
+		Operation: &proto.Operation_List{
+			List: &proto.OperationList{
+				KeyStart: s.makeSecondaryKey(secondaryStart),
+				KeyEnd:   s.makeSecondaryKey(secondaryEnd),
+				UseIndex: &indexName,
+			},
+		},
+	}, true
+}
+
+// lookup returns the formatted primary indexes whose secondary key falls in
+// [secondaryStart, secondaryEnd), ordered by secondary key and then primary key.
+func (s *secondaryIndex) lookup(secondaryStart int64, secondaryEnd int64) []string {
+	entries := s.index.RangeScan(s.makeSecondaryKey(secondaryStart), s.makeSecondaryKey(secondaryEnd))
+	primaries := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		primaries = append(primaries, entry.Value)
+	}
+	return primaries
+}
+
+func (s *secondaryIndex) unindex(primary string) {
+	secondaryKey, exist := s.secondaryKeys[primary]
+	if !exist {
+		return
+	}
+	s.index.Delete(secondaryKey + "/" + primary)
+	delete(s.secondaryKeys, primary)
+}
+
+func (s *secondaryIndex) makeSecondaryKey(index int64) string {
+	return fmt.Sprintf("%s-%010d", s.name, index)
+}
+
+func (s *secondaryIndex) nextSequence() int64 {
+	nextSequence := s.sequence
+	s.sequence = s.sequence + 1
+	return nextSequence
+}
+
+func NewSecondaryIndex(ctx context.Context, tc *config.TestCaseConfig) (Generator, error) {
+	logger := slog.With("generator", "secondary-index", "name", tc.Name)
+
+	keySpace := int64(1000)
+	indexSpace := int64(100)
+	if properties := tc.Properties; properties != nil {
+		if num, exist := properties[propertiesKeyKeySpace]; exist {
+			intVal, err := strconv.ParseInt(num, 10, 64)
+			if err != nil || intVal <= 0 {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a positive integer", propertiesKeyKeySpace, num)
+			}
+			keySpace = intVal
+		}
+		if num, exist := properties[propertiesKeyIndexSpace]; exist {
+			intVal, err := strconv.ParseInt(num, 10, 64)
+			if err != nil || intVal <= 0 {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a positive integer", propertiesKeyIndexSpace, num)
+			}
+			indexSpace = intVal
+		}
+	}
+
+	logger.Info("Starting secondary index generator", "keySpace", keySpace, "indexSpace", indexSpace)
+
+	actionGenerator, err := NewActionGeneratorFromProperties(tc.Properties, map[OpType]int{
+		OpPut:         40,
+		OpDelete:      10,
+		OpDeleteRange: 5,
+		OpGet:         20,
+		OpList:        25,
+	})
+	if err != nil {
+		return nil, err
+	}
+	values, err := NewValueGenerator(tc.Name, tc.Properties, logger)
+	if err != nil {
+		return nil, err
+	}
+	keyChooser, err := NewKeyChooser(keySpace, tc.Properties)
+	if err != nil {
+		return nil, err
+	}
+	return NewPhasedGenerator(ctx, tc, &secondaryIndex{
+		logger:          logger,
+		actionGenerator: actionGenerator,
+		name:            tc.Name,
+		keySpace:        keySpace,
+		indexSpace:      indexSpace,
+		keyChooser:      keyChooser,
+		values:          values,
+		data:            NewDataTree(),
+		secondaryKeys:   make(map[string]string),
+		index:           NewDataTree(),
+	}, logger)
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+)
+
+func TestSecondaryIndexProperties(t *testing.T) {
+	for _, test := range []struct {
+		property string
+		value    string
+		valid    bool
+	}{
+		{property: propertiesKeyIndexSpace, value: "1", valid: true},
+		{property: propertiesKeyIndexSpace, value: "100", valid: true},
+		{property: propertiesKeyIndexSpace, value: "0"},
+		{property: propertiesKeyIndexSpace, value: "-1"},
+		{property: propertiesKeyIndexSpace, value: "x"},
+		{property: propertiesKeyIndexSpace, value: ""},
+		{property: propertiesKeyKeySpace, value: "1", valid: true},
+		{property: propertiesKeyKeySpace, value: "0"},
+		{property: propertiesKeyKeySpace, value: "-5"},
+		{property: propertiesKeyKeySpace, value: "1e3"},
+	} {
+		t.Run(test.property+"="+test.value, func(t *testing.T) {
+			_, err := NewSecondaryIndex(context.Background(), &config.TestCaseConfig{
+				Name:       "secondary",
+				Type:       config.TestCaseTypeSecondaryIndex,
+				Properties: map[string]string{test.property: test.value},
+			})
+			if test.valid && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !test.valid && !errors.Is(err, ErrInvalidProperty) {
+				t.Fatalf("got error %v, expected ErrInvalidProperty", err)
+			}
+		})
+	}
+}
@@ -5,14 +5,18 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand"
+	"strconv"
 	"time"
 
 	"github.com/oxia-io/okk/coordinator/internal/config"
 	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
 	"golang.org/x/time/rate"
 )
 
-var _ Generator = &streamingSequence{}
+const propertiesKeySequenceUpdatesBatch = "sequenceUpdatesBatch"
+
+var _ CapabilityAwareGenerator = &streamingSequence{}
 
 type streamingSequence struct {
 	ctx      context.Context
@@ -26,12 +30,28 @@ type streamingSequence struct {
 
 	sequence    int64
 	needsCleanup bool
+
+	// sequenceUpdatesBatch is the number of puts observed by each sequence
+	// updates subscription before it is stopped and verified. 0 disables it.
+	sequenceUpdatesBatch int
+	// sequenceUpdatesWindow is how long the worker waits for the updates of
+	// a batch once it is stopped.
+	sequenceUpdatesWindow *proto.Eventually
+	subscribed            bool
+	producedKeys          []string
 }
 
 func (s *streamingSequence) Name() string {
 	return "streaming-sequence"
 }
 
+func (s *streamingSequence) Requirements() Requirements {
+	return Requirements{
+		Operations: []string{"put", "deleteRange", "sequenceUpdates"},
+		Assertions: []string{"put.records", "sequenceUpdates.sequenceUpdates"},
+	}
+}
+
 func (s *streamingSequence) Next() (*proto.Operation, bool) {
 	if s.needsCleanup {
 		s.needsCleanup = false
@@ -40,7 +60,6 @@ func (s *streamingSequence) Next() (*proto.Operation, bool) {
 		keyEnd := s.taskName + "~"
 		return &proto.Operation{
 			Timestamp: time.Now().UnixNano(),
-			Sequence:  0,
 			Operation: &proto.Operation_DeleteRange{
 				DeleteRange: &proto.OperationDeleteRange{
 					KeyStart: keyStart,
@@ -58,6 +77,21 @@ func (s *streamingSequence) Next() (*proto.Operation, bool) {
 		s.logger.Error("Failed to wait for rate limiter", "error", err)
 		return nil, false
 	}
+	if s.sequenceUpdatesBatch > 0 {
+		if !s.subscribed {
+			s.subscribed = true
+			s.producedKeys = make([]string, 0, s.sequenceUpdatesBatch)
+			return s.sequenceUpdates(proto.SubscriptionAction_SUBSCRIBE, nil), true
+		}
+		if len(s.producedKeys) >= s.sequenceUpdatesBatch {
+			s.subscribed = false
+			return s.sequenceUpdates(proto.SubscriptionAction_UNSUBSCRIBE, &proto.Assertion{
+				SequenceUpdates: s.producedKeys,
+				Eventually:      s.sequenceUpdatesWindow,
+			}), true
+		}
+	}
+
 	sequence := s.nextSequence()
 
 	b := make([]byte, 8)
@@ -68,9 +102,12 @@ func (s *streamingSequence) Next() (*proto.Operation, bool) {
 
 	partitionKey := s.taskName
 	bypassIfExist := true
+	sequenceKey := fmt.Sprintf("%s-%020d-%020d-%020d", s.taskName, sequence, sequence*2, sequence*3)
+	if s.subscribed {
+		s.producedKeys = append(s.producedKeys, sequenceKey)
+	}
 	return &proto.Operation{
 		Timestamp: time.Now().UnixNano(),
-		Sequence:  sequence,
 		Operation: &proto.Operation_Put{
 			Put: &proto.OperationPut{
 				Key:              s.taskName,
@@ -85,7 +122,7 @@ func (s *streamingSequence) Next() (*proto.Operation, bool) {
 		Assertion: &proto.Assertion{
 			Records: []*proto.Record{
 				{
-					Key:   fmt.Sprintf("%s-%020d-%020d-%020d", s.taskName, sequence, sequence*2, sequence*3),
+					Key:   sequenceKey,
 					Value: b,
 				},
 			},
@@ -94,18 +131,59 @@ func (s *streamingSequence) Next() (*proto.Operation, bool) {
 	}, true
 }
 
+func (s *streamingSequence) sequenceUpdates(action proto.SubscriptionAction, assertion *proto.Assertion) *proto.Operation {
+	return &proto.Operation{
+		Timestamp: time.Now().UnixNano(),
+		Assertion: assertion,
+		Operation: &proto.Operation_SequenceUpdates{
+			SequenceUpdates: &proto.OperationSequenceUpdates{
+				Key:          s.taskName,
+				PartitionKey: s.taskName,
+				Action:       action,
+			},
+		},
+	}
+}
+
 func (s *streamingSequence) nextSequence() int64 {
 	nextSequence := s.sequence
 	s.sequence = s.sequence + 1
 	return nextSequence
 }
 
-func NewStreamingSequence(ctx context.Context, tc *config.TestCaseConfig) Generator {
-	currentContext, currentContextCanceled := context.WithCancel(ctx)
+func NewStreamingSequence(ctx context.Context, tc *config.TestCaseConfig) (Generator, error) {
 	logger := slog.With("generator", "streaming-sequence", "name", tc.Name)
-	logger.Info("Starting streaming sequence generator")
+	if err := rejectOpWeights(tc.Properties); err != nil {
+		return nil, err
+	}
+
+	sequenceUpdatesBatch := 0
+	if properties := tc.Properties; properties != nil {
+		if num, exist := properties[propertiesKeySequenceUpdatesBatch]; exist {
+			intVal, err := strconv.Atoi(num)
+			if err != nil || intVal < 0 {
+				return nil, errors.Wrapf(ErrInvalidProperty, "%s %q is not a non-negative integer", propertiesKeySequenceUpdatesBatch, num)
+			}
+			sequenceUpdatesBatch = intVal
+		}
+	}
+
+	// the worker waits for the updates before responding, so the coordinator
+	// must not time the operation out first
+	sequenceUpdatesWindow, err := newEventually(tc.Properties, propertiesKeySequenceUpdatesTimeout, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	sequenceUpdatesTimeout := time.Duration(sequenceUpdatesWindow.TimeoutMillis) * time.Millisecond
+	if opTimeout := tc.GetOpTimeout("sequenceUpdates"); sequenceUpdatesBatch > 0 && sequenceUpdatesTimeout >= opTimeout {
+		return nil, errors.Wrapf(ErrInvalidProperty, "%s %s must be shorter than the sequenceUpdates timeout %s",
+			propertiesKeySequenceUpdatesTimeout, sequenceUpdatesTimeout, opTimeout)
+	}
+
+	logger.Info("Starting streaming sequence generator", "sequenceUpdatesBatch", sequenceUpdatesBatch)
 
 	opRate := tc.GetOpRate()
+	currentContext, currentContextCanceled := context.WithCancel(ctx)
 	return &streamingSequence{
 		logger:    logger,
 		ctx:       currentContext,
@@ -116,5 +194,8 @@ func NewStreamingSequence(ctx context.Context, tc *config.TestCaseConfig) Genera
 		rateLimit: rate.NewLimiter(rate.Limit(opRate), opRate),
 		sequence:     1,
 		needsCleanup: true,
-	}
+
+		sequenceUpdatesBatch:  sequenceUpdatesBatch,
+		sequenceUpdatesWindow: sequenceUpdatesWindow,
+	}, nil
 }
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+)
+
+func TestStreamingSequenceProperties(t *testing.T) {
+	for _, test := range []struct {
+		properties map[string]string
+		valid      bool
+	}{
+		{properties: nil, valid: true},
+		{properties: map[string]string{"sequenceUpdatesBatch": "0"}, valid: true},
+		{properties: map[string]string{"sequenceUpdatesBatch": "10"}, valid: true},
+		{properties: map[string]string{"sequenceUpdatesBatch": "-1"}},
+		{properties: map[string]string{"sequenceUpdatesBatch": "ten"}},
+	} {
+		_, err := NewStreamingSequence(context.Background(), &config.TestCaseConfig{Name: "sequence", Properties: test.properties})
+		if test.valid && err != nil {
+			t.Errorf("properties %v: unexpected error %v", test.properties, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidProperty) {
+			t.Errorf("properties %v: got error %v, expected ErrInvalidProperty", test.properties, err)
+		}
+	}
+}
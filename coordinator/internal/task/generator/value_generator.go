@@ -0,0 +1,194 @@
+package generator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	propertiesKeyValueSizeDistribution = "valueSizeDistribution"
+	propertiesKeyValueSize             = "valueSize"
+	propertiesKeyValueSizeMin          = "valueSizeMin"
+	propertiesKeyValueSizeMax          = "valueSizeMax"
+	propertiesKeyValueSizeStdDev       = "valueSizeStdDev"
+)
+
+// Value size distributions accepted by the valueSizeDistribution property.
+const (
+	ValueSizeFixed   = "fixed"
+	ValueSizeUniform = "uniform"
+	ValueSizeNormal  = "normal"
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// ValueGenerator produces the values written by a generator together with
+// the digest its model keeps in place of the value.
+//
+// Without a valueSizeDistribution property values are the small
+// "<name>-<uuid>" strings and the digest is the uuid. Otherwise values are
+// "<name>-<crc32c of payload>-" followed by a random payload, padded to the
+// chosen size, and the digest is the checksum and length of the whole value.
+type ValueGenerator struct {
+	name   string
+	logger *slog.Logger
+	sizer  func() int
+	rand   *rand.ChaCha8
+}
+
+// Next returns a new value and its digest.
+func (v *ValueGenerator) Next() ([]byte, string) {
+	if v.sizer == nil {
+		uid := uuid.New().String()
+		return makeValue(v.name, uid), uid
+	}
+
+	size := v.sizer()
+	header := len(v.name) + 10
+	payload := make([]byte, max(0, size-header))
+	_, _ = v.rand.Read(payload)
+	value := make([]byte, 0, header+len(payload))
+	value = fmt.Appendf(value, "%s-%08x-", v.name, crc32.Checksum(payload, castagnoli))
+	value = append(value, payload...)
+	return value, fmt.Sprintf("%08x:%d", crc32.Checksum(value, castagnoli), len(value))
+}
+
+// Digest returns the digest of a value read from the store, which matches the
+// digest returned by Next for the same value.
+func (v *ValueGenerator) Digest(value []byte) string {
+	if v.sizer == nil {
+		return strings.TrimPrefix(string(value), v.name+"-")
+	}
+	return fmt.Sprintf("%08x:%d", crc32.Checksum(value, castagnoli), len(value))
+}
+
+// Record returns the assertion record for a key holding the value with digest.
+func (v *ValueGenerator) Record(key string, digest string) *proto.Record {
+	if v.sizer == nil {
+		return &proto.Record{
+			Key:   key,
+			Value: makeValue(v.name, digest),
+		}
+	}
+
+	checksum, length, err := parseDigest(digest)
+	if err != nil {
+		// the model is corrupt, so the assertion fails on an expected value
+		// naming the cause
+		v.logger.Error("Malformed value digest", "key", key, "digest", digest, "error", err)
+		return &proto.Record{
+			Key:   key,
+			Value: []byte(err.Error()),
+		}
+	}
+	return &proto.Record{
+		Key:           key,
+		ValueChecksum: &checksum,
+		ValueLength:   &length,
+	}
+}
+
+// parseDigest parses the "<crc32c>:<length>" digest of a sized value.
+func parseDigest(digest string) (uint32, uint64, error) {
+	checksumHex, lengthStr, found := strings.Cut(digest, ":")
+	if !found || len(checksumHex) != 8 {
+		return 0, 0, fmt.Errorf("malformed value digest %q, expected <crc32c>:<length>", digest)
+	}
+	checksum, err := strconv.ParseUint(checksumHex, 16, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed checksum in value digest %q", digest)
+	}
+	length, err := strconv.ParseUint(lengthStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed length in value digest %q", digest)
+	}
+	return uint32(checksum), length, nil
+}
+
+// NewValueGenerator builds the ValueGenerator described by the value size properties.
+func NewValueGenerator(name string, properties map[string]string, logger *slog.Logger) (*ValueGenerator, error) {
+	v := &ValueGenerator{
+		name:   name,
+		logger: logger,
+	}
+	distribution, exist := properties[propertiesKeyValueSizeDistribution]
+	if !exist {
+		return v, nil
+	}
+
+	size, err := parseSize(properties, propertiesKeyValueSize, 1024)
+	if err != nil {
+		return nil, err
+	}
+	minSize, err := parseSize(properties, propertiesKeyValueSizeMin, 0)
+	if err != nil {
+		return nil, err
+	}
+	maxSize, err := parseSize(properties, propertiesKeyValueSizeMax, 4*size)
+	if err != nil {
+		return nil, err
+	}
+	switch distribution {
+	case ValueSizeFixed:
+		v.sizer = func() int { return size }
+	case ValueSizeUniform, ValueSizeNormal:
+		if minSize > maxSize {
+			return nil, errors.Wrapf(ErrInvalidProperty, "%s %d is greater than %s %d",
+				propertiesKeyValueSizeMin, minSize, propertiesKeyValueSizeMax, maxSize)
+		}
+		if distribution == ValueSizeUniform {
+			v.sizer = func() int { return minSize + rand.IntN(maxSize-minSize+1) }
+			break
+		}
+		stdDev, err := parseSize(properties, propertiesKeyValueSizeStdDev, size/4)
+		if err != nil {
+			return nil, err
+		}
+		v.sizer = func() int {
+			sample := rand.NormFloat64()*float64(stdDev) + float64(size)
+			return int(math.Max(float64(minSize), math.Min(float64(maxSize), sample)))
+		}
+	default:
+		return nil, errors.Wrapf(ErrInvalidProperty, "unknown %s %q, expected one of %s, %s, %s",
+			propertiesKeyValueSizeDistribution, distribution, ValueSizeFixed, ValueSizeUniform, ValueSizeNormal)
+	}
+
+	var seed [32]byte
+	binary.LittleEndian.PutUint64(seed[:], rand.Uint64())
+	binary.LittleEndian.PutUint64(seed[8:], rand.Uint64())
+	v.rand = rand.NewChaCha8(seed)
+	logger.Info("Using sized values", "distribution", distribution, "size", size, "min", minSize, "max", maxSize)
+	return v, nil
+}
+
+// parseSize parses a byte size such as "512", "64KiB" or "4MiB".
+func parseSize(properties map[string]string, key string, defaultValue int) (int, error) {
+	value, exist := properties[key]
+	if !exist {
+		return defaultValue, nil
+	}
+	multiplier := 1
+	number := value
+	for suffix, m := range map[string]int{"KiB": 1 << 10, "MiB": 1 << 20, "GiB": 1 << 30} {
+		if strings.HasSuffix(value, suffix) {
+			number = strings.TrimSuffix(value, suffix)
+			multiplier = m
+			break
+		}
+	}
+	intVal, err := strconv.Atoi(number)
+	if err != nil || intVal < 0 {
+		return 0, errors.Wrapf(ErrInvalidProperty, "%s %q is not a size such as 512, 64KiB or 4MiB", key, value)
+	}
+	return intVal * multiplier, nil
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"bytes"
+	"hash/crc32"
+	"log/slog"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestValueDigestRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		properties map[string]string
+		sized      bool
+		minSize    int
+		maxSize    int
+	}{
+		{name: "small", properties: nil},
+		{name: "fixed", properties: map[string]string{propertiesKeyValueSizeDistribution: ValueSizeFixed, propertiesKeyValueSize: "4KiB"},
+			sized: true, minSize: 4096, maxSize: 4096},
+		{name: "fixed below the header", properties: map[string]string{propertiesKeyValueSizeDistribution: ValueSizeFixed, propertiesKeyValueSize: "0"},
+			sized: true, minSize: 0, maxSize: 64},
+		{name: "uniform", properties: map[string]string{propertiesKeyValueSizeDistribution: ValueSizeUniform,
+			propertiesKeyValueSizeMin: "100", propertiesKeyValueSizeMax: "200"}, sized: true, minSize: 100, maxSize: 200},
+		{name: "normal", properties: map[string]string{propertiesKeyValueSizeDistribution: ValueSizeNormal,
+			propertiesKeyValueSize: "1KiB", propertiesKeyValueSizeMin: "512", propertiesKeyValueSizeMax: "2KiB"},
+			sized: true, minSize: 512, maxSize: 2048},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := NewValueGenerator("values", test.properties, slog.Default())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range 100 {
+				value, digest := values.Next()
+				if actual := values.Digest(value); actual != digest {
+					t.Fatalf("digest of the value read back is %q, expected %q", actual, digest)
+				}
+				record := values.Record("key", digest)
+				if !test.sized {
+					if !bytes.Equal(record.Value, value) {
+						t.Fatalf("record value %q, expected %q", record.Value, value)
+					}
+					continue
+				}
+				if len(value) < test.minSize || len(value) > test.maxSize {
+					t.Fatalf("value size %d is out of [%d, %d]", len(value), test.minSize, test.maxSize)
+				}
+				if record.Value != nil || record.GetValueChecksum() != crc32.Checksum(value, castagnoli) ||
+					record.GetValueLength() != uint64(len(value)) {
+					t.Fatalf("record %v does not describe the value", record)
+				}
+			}
+		})
+	}
+}
+
+func TestValueGeneratorInvalidProperties(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		properties map[string]string
+	}{
+		{"unknown distribution", map[string]string{propertiesKeyValueSizeDistribution: "zipfian"}},
+		{"malformed size", map[string]string{propertiesKeyValueSizeDistribution: ValueSizeFixed, propertiesKeyValueSize: "4MB"}},
+		{"negative size", map[string]string{propertiesKeyValueSizeDistribution: ValueSizeFixed, propertiesKeyValueSize: "-1"}},
+		{"malformed min", map[string]string{propertiesKeyValueSizeDistribution: ValueSizeUniform, propertiesKeyValueSizeMin: "x"}},
+		{"malformed max", map[string]string{propertiesKeyValueSizeDistribution: ValueSizeUniform, propertiesKeyValueSizeMax: "1.5KiB"}},
+		{"min above max", map[string]string{propertiesKeyValueSizeDistribution: ValueSizeUniform,
+			propertiesKeyValueSizeMin: "2KiB", propertiesKeyValueSizeMax: "1KiB"}},
+		{"min above default max", map[string]string{propertiesKeyValueSizeDistribution: ValueSizeNormal,
+			propertiesKeyValueSize: "1KiB", propertiesKeyValueSizeMin: "8KiB"}},
+		{"malformed stdDev", map[string]string{propertiesKeyValueSizeDistribution: ValueSizeNormal, propertiesKeyValueSizeStdDev: "wide"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewValueGenerator("values", test.properties, slog.Default()); !errors.Is(err, ErrInvalidProperty) {
+				t.Fatalf("got error %v, expected ErrInvalidProperty", err)
+			}
+		})
+	}
+}
+
+func TestParseDigest(t *testing.T) {
+	for _, test := range []struct {
+		digest   string
+		checksum uint32
+		length   uint64
+		invalid  bool
+	}{
+		{digest: "0000abcd:42", checksum: 0xabcd, length: 42},
+		{digest: "ffffffff:0", checksum: 0xffffffff, length: 0},
+		{digest: "", invalid: true},
+		{digest: "abcd:42", invalid: true},
+		{digest: "0000abcd", invalid: true},
+		{digest: "0000abcg:42", invalid: true},
+		{digest: "0000abcd:-1", invalid: true},
+		{digest: "0000abcd:42x", invalid: true},
+		{digest: "1234-uuid", invalid: true},
+	} {
+		checksum, length, err := parseDigest(test.digest)
+		if test.invalid {
+			if err == nil {
+				t.Errorf("parseDigest(%q) succeeded, expected an error", test.digest)
+			}
+			continue
+		}
+		if err != nil || checksum != test.checksum || length != test.length {
+			t.Errorf("parseDigest(%q) = %x, %d, %v, expected %x, %d", test.digest, checksum, length, err, test.checksum, test.length)
+		}
+	}
+}
+
+func TestRecordOfMalformedDigest(t *testing.T) {
+	values, err := NewValueGenerator("values", map[string]string{propertiesKeyValueSizeDistribution: ValueSizeFixed}, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := values.Record("key", "not-a-digest")
+	if record.ValueChecksum != nil || record.ValueLength != nil || !bytes.Contains(record.Value, []byte("malformed value digest")) {
+		t.Fatalf("record %v does not name the malformed digest", record)
+	}
+}
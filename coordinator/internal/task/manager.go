@@ -8,20 +8,35 @@ import (
 	"time"
 
 	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/oxia-io/okk/coordinator/internal/proto"
 	"github.com/oxia-io/okk/coordinator/internal/task/generator"
+	"github.com/pkg/errors"
 )
 
+var ErrInvalidTestCase = errors.New("invalid testcase")
+
+// describeTimeout bounds how long creating a testcase waits for its worker to describe itself.
+const describeTimeout = 5 * time.Second
+
 type TaskStatus struct {
-	Name             string     `json:"name"`
-	Type             string     `json:"type"`
-	Namespace        string     `json:"namespace"`
-	WorkerEndpoint   string     `json:"workerEndpoint"`
-	State            string     `json:"state"`
-	Operations       int64      `json:"operations"`
-	AssertionsPassed int64      `json:"assertions_passed"`
-	AssertionsFailed int64      `json:"assertions_failed"`
-	RunningSince     *time.Time `json:"running_since"`
-	LastFailure      *string    `json:"last_failure"`
+	Name             string              `json:"name"`
+	Type             string              `json:"type"`
+	Namespace        string              `json:"namespace"`
+	WorkerEndpoint   string              `json:"workerEndpoint"`
+	Axes             map[string]string   `json:"axes,omitempty"`
+	State            string              `json:"state"`
+	StateReason      string              `json:"state_reason,omitempty"`
+	Phase            string              `json:"phase,omitempty"`
+	Operations       int64               `json:"operations"`
+	AssertionsPassed int64               `json:"assertions_passed"`
+	AssertionsFailed int64               `json:"assertions_failed"`
+	Timeouts         int64               `json:"timeouts"`
+	Retries          int64               `json:"retries"`
+	NonRetryable     int64               `json:"non_retryable_failures"`
+	Resyncs          int64               `json:"resyncs"`
+	RunningSince     *time.Time          `json:"running_since"`
+	LastFailure      *string             `json:"last_failure"`
+	Failures         []*AssertionFailure `json:"failures,omitempty"`
 }
 
 type Manager struct {
@@ -30,46 +45,145 @@ type Manager struct {
 	cancel          context.CancelFunc
 	tasks           map[string]Task
 	configs         map[string]*config.TestCaseConfig
-	statuses        map[string]*TaskStatus
 	providerManager *ProviderManager
+	registry        *Registry
+	events          *EventBus
+	webhooks        *WebhookDispatcher
+	// reserved holds the names of the testcases being created.
+	reserved map[string]struct{}
+	suites   map[string]*suite
+	// suiteOf maps the testcases created by a suite to its name.
+	suiteOf map[string]string
 }
 
 func (m *Manager) CreateTask(tc *config.TestCaseConfig) error {
+	m.mu.Lock()
+	err := m.reserve(tc.Name)
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	prepared, err := m.prepareTask(tc)
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	delete(m.reserved, tc.Name)
+	if err != nil {
+		return err
+	}
+	m.startTask(prepared)
+	return nil
+}
 
-	if _, exist := m.tasks[tc.Name]; exist {
-		return fmt.Errorf("testcase %q already exists", tc.Name)
+// reserve claims the name of a testcase about to be created, with m.mu held,
+// so that it is not taken while the testcase is prepared without m.mu.
+func (m *Manager) reserve(name string) error {
+	_, exist := m.tasks[name]
+	_, reserved := m.reserved[name]
+	if exist || reserved {
+		return fmt.Errorf("testcase %q already exists", name)
 	}
+	m.reserved[name] = struct{}{}
+	return nil
+}
+
+// preparedTask is a testcase validated and checked against its worker,
+// ready to start.
+type preparedTask struct {
+	config        *config.TestCaseConfig
+	generator     generator.Generator
+	assertionMode proto.AssertionMode
+	worker        string
+}
 
-	gen := m.createGenerator(tc)
-	if gen == nil {
-		return fmt.Errorf("unknown testcase type: %s", tc.Type)
+// prepareTask validates a testcase, creates its generator and checks its
+// worker. It runs without m.mu, as the check may take up to describeTimeout,
+// and with the name of the testcase reserved.
+func (m *Manager) prepareTask(tc *config.TestCaseConfig) (*preparedTask, error) {
+	var assertionMode proto.AssertionMode
+	switch tc.AssertionMode {
+	case "", config.AssertionModeWorker:
+		assertionMode = proto.AssertionMode_WORKER
+	case config.AssertionModeCoordinator:
+		assertionMode = proto.AssertionMode_COORDINATOR
+	default:
+		return nil, errors.Wrapf(ErrInvalidTestCase, "unknown assertion mode: %s", tc.AssertionMode)
 	}
 
+	if (tc.WorkerEndpoint == "") == (len(tc.WorkerSelector) == 0) {
+		return nil, errors.Wrap(ErrInvalidTestCase, "exactly one of workerEndpoint and workerSelector must be set")
+	}
+	if err := ValidateWorkerAuth(tc.WorkerAuth); err != nil {
+		return nil, errors.Wrap(ErrInvalidTestCase, err.Error())
+	}
+	if err := tc.ValidateOpTimeouts(); err != nil {
+		return nil, errors.Wrap(ErrInvalidTestCase, err.Error())
+	}
+	if err := tc.GetFailurePolicy().Validate(); err != nil {
+		return nil, errors.Wrap(ErrInvalidTestCase, err.Error())
+	}
+
+	gen, err := m.createGenerator(tc)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidTestCase, err.Error())
+	}
+	// a testcase selecting its worker is checked against the worker it is
+	// assigned, if any is registered yet
+	worker := tc.WorkerEndpoint
+	if len(tc.WorkerSelector) > 0 {
+		worker, _ = m.registry.Assign(tc.Name, tc.WorkerSelector)
+	}
+	if worker != "" {
+		if err := m.checkWorker(tc, worker, gen, assertionMode); err != nil {
+			m.registry.Unassign(tc.Name)
+			return nil, errors.Wrap(ErrInvalidTestCase, err.Error())
+		}
+	}
+	return &preparedTask{config: tc, generator: gen, assertionMode: assertionMode, worker: worker}, nil
+}
+
+// startTask starts a prepared testcase, with m.mu held.
+func (m *Manager) startTask(p *preparedTask) {
+	tc := p.config
 	now := time.Now()
-	m.statuses[tc.Name] = &TaskStatus{
+	status := &TaskStatus{
 		Name:           tc.Name,
 		Type:           tc.Type,
 		Namespace:      tc.Namespace,
-		WorkerEndpoint: tc.WorkerEndpoint,
-		State:          "running",
+		WorkerEndpoint: p.worker,
+		Axes:           tc.Axes,
+		State:          StateRunning,
 		RunningSince:   &now,
 	}
 
-	newTask := NewTask(m.ctx, m.providerManager, tc.Name, tc.Namespace, gen, tc.WorkerEndpoint, m.statuses[tc.Name])
+	newTask := NewTask(m.ctx, m.providerManager, m.registry, m.events, tc, p.generator, p.assertionMode, status)
 	m.tasks[tc.Name] = newTask
 	m.configs[tc.Name] = tc
 
+	m.events.Publish(&Event{Type: EventState, Testcase: tc.Name, State: StateRunning})
 	newTask.Run()
-	slog.Info("Task created and started", "name", tc.Name, "type", tc.Type, "worker", tc.WorkerEndpoint)
-	return nil
+	slog.Info("Task created and started", "name", tc.Name, "type", tc.Type, "worker", p.worker)
+}
+
+// abandon releases the worker of a prepared testcase that is not started.
+func (m *Manager) abandon(p *preparedTask) {
+	m.registry.Unassign(p.config.Name)
+	m.providerManager.Release(p.config.Name)
 }
 
 func (m *Manager) DeleteTask(name string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if suiteName, ok := m.suiteOf[name]; ok {
+		return errors.Wrapf(ErrSuiteMember, "testcase %q belongs to suite %q, delete the suite instead", name, suiteName)
+	}
+	return m.deleteTask(name)
+}
+
+// deleteTask stops and forgets a testcase, with m.mu held.
+func (m *Manager) deleteTask(name string) error {
 	t, exist := m.tasks[name]
 	if !exist {
 		return fmt.Errorf("testcase %q not found", name)
@@ -78,9 +192,10 @@ func (m *Manager) DeleteTask(name string) error {
 	if err := t.Close(); err != nil {
 		slog.Error("Failed to close task", "name", name, "error", err)
 	}
+	m.registry.Unassign(name)
 	delete(m.tasks, name)
 	delete(m.configs, name)
-	delete(m.statuses, name)
+	m.events.Publish(&Event{Type: EventDeleted, Testcase: name})
 
 	slog.Info("Task deleted", "name", name)
 	return nil
@@ -90,22 +205,98 @@ func (m *Manager) GetStatus(name string) (*TaskStatus, bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	status, exist := m.statuses[name]
-	return status, exist
+	t, exist := m.tasks[name]
+	if !exist {
+		return nil, false
+	}
+	return t.Status(), true
+}
+
+// GetConfig returns the config a testcase was created with.
+func (m *Manager) GetConfig(name string) (*config.TestCaseConfig, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tc, exist := m.configs[name]
+	return tc, exist
 }
 
 func (m *Manager) ListStatuses() []*TaskStatus {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	result := make([]*TaskStatus, 0, len(m.statuses))
-	for _, s := range m.statuses {
-		result = append(result, s)
+	result := make([]*TaskStatus, 0, len(m.tasks))
+	for _, t := range m.tasks {
+		result = append(result, t.Status())
 	}
 	return result
 }
 
-func (m *Manager) createGenerator(tc *config.TestCaseConfig) generator.Generator {
+// checkWorker rejects a testcase whose worker lacks the capabilities its
+// generator needs. An unreachable worker is checked by the task once it
+// connects instead, so that testcases can be created before their workers.
+func (m *Manager) checkWorker(tc *config.TestCaseConfig, worker string, gen generator.Generator, assertionMode proto.AssertionMode) error {
+	client, err := m.providerManager.Acquire(tc.Name, worker, tc.WorkerAuth)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(m.ctx, describeTimeout)
+	defer cancel()
+	err = checkCapabilities(ctx, client, gen, assertionMode, slog.With("task", tc.Name))
+	if errors.Is(err, ErrUnsupportedByWorker) {
+		m.providerManager.Release(tc.Name)
+		return err
+	}
+	if err != nil {
+		slog.Warn("Failed to describe the worker, deferring the capability check", "name", tc.Name, "worker", worker, "error", err)
+	}
+	return nil
+}
+
+// ListWorkers returns the state of the connections to the workers.
+func (m *Manager) ListWorkers() []*WorkerStatus {
+	return m.providerManager.ListWorkers()
+}
+
+// Registry returns the workers that registered themselves.
+func (m *Manager) Registry() *Registry {
+	return m.registry
+}
+
+// Events returns the changes of the testcases as they happen.
+func (m *Manager) Events() *EventBus {
+	return m.events
+}
+
+// Webhooks returns the webhooks called on the failures and completions of the testcases.
+func (m *Manager) Webhooks() *WebhookDispatcher {
+	return m.webhooks
+}
+
+// Close stops every testcase, then closes the connections to the workers.
+func (m *Manager) Close() error {
+	// the dispatcher is closed first and without m.mu, so that the events
+	// still in flight do not hold the shutdown
+	if err := m.webhooks.Close(); err != nil {
+		slog.Error("Failed to close the webhook dispatcher", "error", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, t := range m.tasks {
+		if err := t.Close(); err != nil {
+			slog.Error("Failed to close task", "name", name, "error", err)
+		}
+	}
+	m.cancel()
+	if err := m.registry.Close(); err != nil {
+		slog.Error("Failed to close the worker registry", "error", err)
+	}
+	return m.providerManager.Close()
+}
+
+func (m *Manager) createGenerator(tc *config.TestCaseConfig) (generator.Generator, error) {
 	switch tc.Type {
 	case config.TestCaseTypeBasicKv:
 		return generator.NewBasicKv(m.ctx, tc)
@@ -117,20 +308,34 @@ func (m *Manager) createGenerator(tc *config.TestCaseConfig) generator.Generator
 		return generator.NewMetadataEphemeralGenerator(m.ctx, tc)
 	case config.TestCaseTypeConditionalPut:
 		return generator.NewConditionalPut(m.ctx, tc)
+	case config.TestCaseTypeSecondaryIndex:
+		return generator.NewSecondaryIndex(m.ctx, tc)
+	case config.TestCaseTypeHierarchicalKeys:
+		return generator.NewHierarchicalKeys(m.ctx, tc)
+	case config.TestCaseTypeErrorCatalog:
+		return generator.NewErrorCatalog(m.ctx, tc)
 	default:
-		return nil
+		return nil, fmt.Errorf("unknown testcase type: %s", tc.Type)
 	}
 }
 
-func NewManager(ctx context.Context) *Manager {
+// NewManager returns a Manager connecting to workers with workerAuth, unless
+// a testcase has its own. workerAuth may be nil for plaintext connections.
+func NewManager(ctx context.Context, workerAuth *config.WorkerAuth) *Manager {
 	currentContext, currentContextCancel := context.WithCancel(ctx)
 
-	return &Manager{
+	m := &Manager{
 		ctx:             currentContext,
 		cancel:          currentContextCancel,
 		tasks:           make(map[string]Task),
 		configs:         make(map[string]*config.TestCaseConfig),
-		statuses:        make(map[string]*TaskStatus),
-		providerManager: NewProviderManager(),
+		providerManager: NewProviderManager(currentContext, workerAuth, DefaultWorkerIdleTimeout),
+		registry:        NewRegistry(currentContext, DefaultHeartbeatInterval),
+		events:          NewEventBus(currentContext),
+		reserved:        make(map[string]struct{}),
+		suites:          make(map[string]*suite),
+		suiteOf:         make(map[string]string),
 	}
+	m.webhooks = newWebhookDispatcher(currentContext, m.events)
+	return m
 }
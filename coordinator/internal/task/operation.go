@@ -0,0 +1,48 @@
+package task
+
+import "github.com/oxia-io/okk/coordinator/internal/proto"
+
+// operationType returns the name of the type of an operation, as used in
+// the opTimeouts of a testcase.
+func operationType(operation *proto.Operation) string {
+	switch operation.Operation.(type) {
+	case *proto.Operation_Put:
+		return "put"
+	case *proto.Operation_Delete:
+		return "delete"
+	case *proto.Operation_Get:
+		return "get"
+	case *proto.Operation_List:
+		return "list"
+	case *proto.Operation_Scan:
+		return "scan"
+	case *proto.Operation_SessionRestart:
+		return "sessionRestart"
+	case *proto.Operation_DeleteRange:
+		return "deleteRange"
+	case *proto.Operation_SequenceUpdates:
+		return "sequenceUpdates"
+	}
+	return "unknown"
+}
+
+// operationKey returns the key, or the first key of the range, an operation targets.
+func operationKey(operation *proto.Operation) string {
+	switch op := operation.Operation.(type) {
+	case *proto.Operation_Put:
+		return op.Put.Key
+	case *proto.Operation_Delete:
+		return op.Delete.Key
+	case *proto.Operation_Get:
+		return op.Get.Key
+	case *proto.Operation_List:
+		return op.List.KeyStart
+	case *proto.Operation_Scan:
+		return op.Scan.KeyStart
+	case *proto.Operation_DeleteRange:
+		return op.DeleteRange.KeyStart
+	case *proto.Operation_SequenceUpdates:
+		return op.SequenceUpdates.Key
+	}
+	return ""
+}
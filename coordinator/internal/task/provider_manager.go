@@ -1,47 +1,256 @@
 package task
 
 import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/oxia-io/okk/coordinator/internal/config"
 	"github.com/oxia-io/okk/coordinator/internal/proto"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/keepalive"
 )
 
+// DefaultWorkerIdleTimeout is how long a connection no testcase uses is kept open.
+const DefaultWorkerIdleTimeout = 5 * time.Minute
+
+// maxWorkerMessageSize bounds the responses of the workers, above the 4 MiB
+// default of gRPC as a scan reports every record of its range.
+const maxWorkerMessageSize = 64 * 1024 * 1024
+
+// providerKey tells apart the connections to an endpoint made with different auth.
+type providerKey struct {
+	worker string
+	auth   config.WorkerAuth
+}
+
+// workerConnection is a connection to a worker and the testcases using it.
+type workerConnection struct {
+	conn       *grpc.ClientConn
+	client     proto.OkkClient
+	tls        bool
+	state      connectivity.State
+	stateSince time.Time
+	testcases  map[string]struct{}
+	// idleSince is when the last testcase released the connection.
+	idleSince time.Time
+}
+
+// WorkerStatus is the state of the connection to a worker endpoint.
+type WorkerStatus struct {
+	Endpoint   string     `json:"endpoint"`
+	TLS        bool       `json:"tls"`
+	State      string     `json:"state"`
+	StateSince time.Time  `json:"state_since"`
+	Testcases  []string   `json:"testcases"`
+	IdleSince  *time.Time `json:"idle_since,omitempty"`
+}
+
+// ProviderManager shares the connections to the workers between the
+// testcases. A connection is opened by the first testcase acquiring it, and
+// closed once it has been idle for idleTimeout, or at once when it is idle
+// and broken.
 type ProviderManager struct {
 	sync.Mutex
-	providers map[string]proto.OkkClient
+	sync.WaitGroup
+	ctx         context.Context
+	cancel      context.CancelFunc
+	connections map[providerKey]*workerConnection
+	defaultAuth *config.WorkerAuth
+	idleTimeout time.Duration
 }
 
-func (pm *ProviderManager) GetProvider(worker string) (proto.OkkClient, error) {
+// Acquire returns the client of worker for testcase, connecting with auth,
+// or with the default auth of the ProviderManager when auth is nil. The
+// connection is kept open until testcase releases it.
+func (pm *ProviderManager) Acquire(testcase string, worker string, auth *config.WorkerAuth) (proto.OkkClient, error) {
 	pm.Lock()
 	defer pm.Unlock()
-	if p, ok := pm.providers[worker]; ok {
-		return p, nil
+	if auth == nil {
+		auth = pm.defaultAuth
+	}
+	key := providerKey{worker: worker}
+	if auth != nil {
+		key.auth = *auth
+	}
+	if c, ok := pm.connections[key]; ok {
+		switch c.conn.GetState() {
+		case connectivity.Shutdown:
+			delete(pm.connections, key)
+		case connectivity.TransientFailure:
+			// the testcase is about to retry, so do not wait for the backoff of the connection
+			c.conn.ResetConnectBackoff()
+			fallthrough
+		default:
+			c.testcases[testcase] = struct{}{}
+			return c.client, nil
+		}
 	}
 
+	transportCredentials, err := newTransportCredentials(auth)
+	if err != nil {
+		return nil, err
+	}
 	options := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transportCredentials),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			PermitWithoutStream: true,
 			Time:                1 * time.Minute,
 			Timeout:             20 * time.Second,
 		}),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxWorkerMessageSize)),
+	}
+	if auth != nil && auth.TokenFile != "" {
+		options = append(options, grpc.WithPerRPCCredentials(&tokenCredentials{tokenFile: auth.TokenFile}))
 	}
-	var provider *grpc.ClientConn
-	var err error
-	if provider, err = grpc.NewClient(worker, options...); err != nil {
+	var conn *grpc.ClientConn
+	if conn, err = grpc.NewClient(worker, options...); err != nil {
 		return nil, err
 	}
-	client := proto.NewOkkClient(provider)
-	pm.providers[worker] = client
-	return client, nil
+	c := &workerConnection{
+		conn:       conn,
+		client:     proto.NewOkkClient(conn),
+		tls:        auth != nil && auth.TLSEnabled(),
+		state:      conn.GetState(),
+		stateSince: time.Now(),
+		testcases:  map[string]struct{}{testcase: {}},
+	}
+	pm.connections[key] = c
+	pm.Add(1)
+	go pm.watch(worker, c)
+	return c.client, nil
+}
+
+// Release drops the references of testcase to its connections.
+func (pm *ProviderManager) Release(testcase string) {
+	pm.Lock()
+	defer pm.Unlock()
+	for _, c := range pm.connections {
+		if _, ok := c.testcases[testcase]; !ok {
+			continue
+		}
+		delete(c.testcases, testcase)
+		if len(c.testcases) == 0 {
+			c.idleSince = time.Now()
+		}
+	}
+}
+
+// watch tracks the connectivity state of c until it is shut down.
+func (pm *ProviderManager) watch(worker string, c *workerConnection) {
+	defer pm.Done()
+	logger := slog.With("worker", worker)
+	state := c.conn.GetState()
+	for c.conn.WaitForStateChange(pm.ctx, state) {
+		previous := state
+		state = c.conn.GetState()
+		logger.Info("Worker connection state changed", "state", state, "previous", previous)
+		pm.Lock()
+		c.state = state
+		c.stateSince = time.Now()
+		pm.Unlock()
+		if state == connectivity.Shutdown {
+			return
+		}
+	}
+}
+
+// evictLoop closes the idle connections until the ProviderManager is closed.
+func (pm *ProviderManager) evictLoop() {
+	defer pm.Done()
+	ticker := time.NewTicker(min(pm.idleTimeout, time.Minute))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pm.ctx.Done():
+			return
+		case <-ticker.C:
+			pm.evict()
+		}
+	}
+}
+
+func (pm *ProviderManager) evict() {
+	pm.Lock()
+	defer pm.Unlock()
+	for key, c := range pm.connections {
+		if len(c.testcases) > 0 {
+			continue
+		}
+		state := c.conn.GetState()
+		if state != connectivity.TransientFailure && state != connectivity.Shutdown &&
+			time.Since(c.idleSince) < pm.idleTimeout {
+			continue
+		}
+		slog.Info("Closing idle worker connection", "worker", key.worker, "state", state, "idle", time.Since(c.idleSince))
+		if err := c.conn.Close(); err != nil {
+			slog.Warn("Failed to close worker connection", "worker", key.worker, "error", err)
+		}
+		delete(pm.connections, key)
+	}
+}
+
+// ListWorkers returns the state of every open connection, ordered by endpoint.
+func (pm *ProviderManager) ListWorkers() []*WorkerStatus {
+	pm.Lock()
+	defer pm.Unlock()
+	result := make([]*WorkerStatus, 0, len(pm.connections))
+	for key, c := range pm.connections {
+		status := &WorkerStatus{
+			Endpoint:   key.worker,
+			TLS:        c.tls,
+			State:      c.state.String(),
+			StateSince: c.stateSince,
+			Testcases:  make([]string, 0, len(c.testcases)),
+		}
+		for testcase := range c.testcases {
+			status.Testcases = append(status.Testcases, testcase)
+		}
+		slices.Sort(status.Testcases)
+		if len(c.testcases) == 0 {
+			idleSince := c.idleSince
+			status.IdleSince = &idleSince
+		}
+		result = append(result, status)
+	}
+	slices.SortFunc(result, func(a, b *WorkerStatus) int {
+		return strings.Compare(a.Endpoint, b.Endpoint)
+	})
+	return result
+}
+
+// Close closes every connection. The testcases must be closed first.
+func (pm *ProviderManager) Close() error {
+	pm.cancel()
+	pm.Lock()
+	for key, c := range pm.connections {
+		if err := c.conn.Close(); err != nil {
+			slog.Warn("Failed to close worker connection", "worker", key.worker, "error", err)
+		}
+		delete(pm.connections, key)
+	}
+	pm.Unlock()
+	pm.Wait()
+	return nil
 }
 
-func NewProviderManager() *ProviderManager {
-	return &ProviderManager{
-		providers: make(map[string]proto.OkkClient),
+// NewProviderManager returns a ProviderManager connecting to workers with
+// defaultAuth, which may be nil for plaintext connections, and closing the
+// connections idle for idleTimeout.
+func NewProviderManager(ctx context.Context, defaultAuth *config.WorkerAuth, idleTimeout time.Duration) *ProviderManager {
+	currentContext, currentContextCancel := context.WithCancel(ctx)
+	pm := &ProviderManager{
+		ctx:         currentContext,
+		cancel:      currentContextCancel,
+		connections: make(map[providerKey]*workerConnection),
+		defaultAuth: defaultAuth,
+		idleTimeout: idleTimeout,
 	}
+	pm.Add(1)
+	go pm.evictLoop()
+	return pm
 }
@@ -0,0 +1,220 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultHeartbeatInterval is how often registered workers heartbeat.
+const DefaultHeartbeatInterval = 10 * time.Second
+
+// missedHeartbeats is how many heartbeats a worker can miss before it is deregistered.
+const missedHeartbeats = 3
+
+// RegisteredWorker is a worker that registered itself with the coordinator.
+type RegisteredWorker struct {
+	ID            string            `json:"id"`
+	Endpoint      string            `json:"endpoint"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	RegisteredAt  time.Time         `json:"registered_at"`
+	LastHeartbeat time.Time         `json:"last_heartbeat"`
+	Testcases     []string          `json:"testcases"`
+}
+
+// Registry keeps the workers that registered themselves, and assigns them to
+// the testcases selecting workers by label.
+type Registry struct {
+	sync.Mutex
+	sync.WaitGroup
+	ctx               context.Context
+	cancel            context.CancelFunc
+	heartbeatInterval time.Duration
+
+	workers map[string]*RegisteredWorker
+	// assignments maps each testcase to the id of the worker it runs on.
+	assignments map[string]string
+}
+
+func (r *Registry) HeartbeatInterval() time.Duration {
+	return r.heartbeatInterval
+}
+
+// Register adds a worker, replacing any previous registration of its endpoint.
+func (r *Registry) Register(endpoint string, labels map[string]string) *RegisteredWorker {
+	r.Lock()
+	defer r.Unlock()
+	for id, worker := range r.workers {
+		if worker.Endpoint == endpoint {
+			delete(r.workers, id)
+		}
+	}
+	now := time.Now()
+	worker := &RegisteredWorker{
+		ID:            uuid.New().String(),
+		Endpoint:      endpoint,
+		Labels:        maps.Clone(labels),
+		RegisteredAt:  now,
+		LastHeartbeat: now,
+	}
+	r.workers[worker.ID] = worker
+	slog.Info("Worker registered", "id", worker.ID, "endpoint", endpoint, "labels", labels)
+	return worker
+}
+
+// Heartbeat records that a worker is alive, or returns false when it is not registered.
+func (r *Registry) Heartbeat(id string) bool {
+	r.Lock()
+	defer r.Unlock()
+	worker, ok := r.workers[id]
+	if ok {
+		worker.LastHeartbeat = time.Now()
+	}
+	return ok
+}
+
+func (r *Registry) Deregister(id string) {
+	r.Lock()
+	defer r.Unlock()
+	if worker, ok := r.workers[id]; ok {
+		delete(r.workers, id)
+		slog.Info("Worker deregistered", "id", id, "endpoint", worker.Endpoint)
+	}
+}
+
+// Assign returns the endpoint of the worker testcase runs on. The testcase
+// stays on its worker while it is registered, and moves to the healthy
+// worker matching selector with the fewest testcases otherwise. It returns
+// false when no registered worker matches.
+func (r *Registry) Assign(testcase string, selector map[string]string) (string, bool) {
+	r.Lock()
+	defer r.Unlock()
+	if worker, ok := r.workers[r.assignments[testcase]]; ok && r.healthy(worker) {
+		return worker.Endpoint, true
+	}
+
+	load := make(map[string]int, len(r.workers))
+	for _, id := range r.assignments {
+		load[id]++
+	}
+	var selected *RegisteredWorker
+	for _, worker := range r.workers {
+		if !r.healthy(worker) || !matches(worker.Labels, selector) {
+			continue
+		}
+		if selected == nil || load[worker.ID] < load[selected.ID] ||
+			(load[worker.ID] == load[selected.ID] && worker.Endpoint < selected.Endpoint) {
+			selected = worker
+		}
+	}
+	if selected == nil {
+		delete(r.assignments, testcase)
+		return "", false
+	}
+	if previous, ok := r.assignments[testcase]; ok {
+		slog.Info("Testcase reassigned to another worker", "testcase", testcase, "previous", previous, "worker", selected.Endpoint)
+	}
+	r.assignments[testcase] = selected.ID
+	return selected.Endpoint, true
+}
+
+// Assigned returns whether testcase is still assigned to the healthy worker at endpoint.
+func (r *Registry) Assigned(testcase string, endpoint string) bool {
+	r.Lock()
+	defer r.Unlock()
+	worker, ok := r.workers[r.assignments[testcase]]
+	return ok && worker.Endpoint == endpoint && r.healthy(worker)
+}
+
+// Unassign drops the assignment of testcase, once it is deleted.
+func (r *Registry) Unassign(testcase string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.assignments, testcase)
+}
+
+// List returns the registered workers, ordered by endpoint.
+func (r *Registry) List() []*RegisteredWorker {
+	r.Lock()
+	defer r.Unlock()
+	testcases := make(map[string][]string, len(r.workers))
+	for testcase, id := range r.assignments {
+		testcases[id] = append(testcases[id], testcase)
+	}
+	result := make([]*RegisteredWorker, 0, len(r.workers))
+	for id, worker := range r.workers {
+		listed := *worker
+		listed.Testcases = testcases[id]
+		if listed.Testcases == nil {
+			listed.Testcases = []string{}
+		}
+		slices.Sort(listed.Testcases)
+		result = append(result, &listed)
+	}
+	slices.SortFunc(result, func(a, b *RegisteredWorker) int {
+		return strings.Compare(a.Endpoint, b.Endpoint)
+	})
+	return result
+}
+
+func (r *Registry) healthy(worker *RegisteredWorker) bool {
+	return time.Since(worker.LastHeartbeat) < missedHeartbeats*r.heartbeatInterval
+}
+
+func matches(labels map[string]string, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// expireLoop deregisters the workers that stopped heartbeating.
+func (r *Registry) expireLoop() {
+	defer r.Done()
+	ticker := time.NewTicker(r.heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.Lock()
+			for id, worker := range r.workers {
+				if !r.healthy(worker) {
+					delete(r.workers, id)
+					slog.Warn("Worker missed its heartbeats, deregistered", "id", id, "endpoint", worker.Endpoint,
+						"last_heartbeat", worker.LastHeartbeat)
+				}
+			}
+			r.Unlock()
+		}
+	}
+}
+
+func (r *Registry) Close() error {
+	r.cancel()
+	r.Wait()
+	return nil
+}
+
+func NewRegistry(ctx context.Context, heartbeatInterval time.Duration) *Registry {
+	currentContext, currentContextCancel := context.WithCancel(ctx)
+	r := &Registry{
+		ctx:               currentContext,
+		cancel:            currentContextCancel,
+		heartbeatInterval: heartbeatInterval,
+		workers:           make(map[string]*RegisteredWorker),
+		assignments:       make(map[string]string),
+	}
+	r.Add(1)
+	go r.expireLoop()
+	return r
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRegistryAssign(t *testing.T) {
+	type assign struct {
+		testcase string
+		selector map[string]string
+		endpoint string
+	}
+	zoneA := map[string]string{"zone": "a"}
+	for _, test := range []struct {
+		name    string
+		workers map[string]map[string]string
+		// stale workers missed their heartbeats
+		stale   []string
+		assigns []assign
+	}{
+		{
+			name:    "no worker",
+			assigns: []assign{{"t1", zoneA, ""}},
+		},
+		{
+			name:    "no matching worker",
+			workers: map[string]map[string]string{"w1:1": {"zone": "b"}},
+			assigns: []assign{{"t1", zoneA, ""}},
+		},
+		{
+			name:    "matching worker",
+			workers: map[string]map[string]string{"w1:1": {"zone": "b"}, "w2:1": {"zone": "a", "disk": "ssd"}},
+			assigns: []assign{{"t1", zoneA, "w2:1"}, {"t2", map[string]string{"zone": "a", "disk": "ssd"}, "w2:1"}},
+		},
+		{
+			name:    "empty selector matches any worker",
+			workers: map[string]map[string]string{"w1:1": nil},
+			assigns: []assign{{"t1", map[string]string{}, "w1:1"}},
+		},
+		{
+			name:    "fewest testcases first, then lowest endpoint",
+			workers: map[string]map[string]string{"w1:1": zoneA, "w2:1": zoneA, "w3:1": {"zone": "b"}},
+			assigns: []assign{{"t1", zoneA, "w1:1"}, {"t2", zoneA, "w2:1"}, {"t3", zoneA, "w1:1"}, {"t4", nil, "w3:1"}},
+		},
+		{
+			name:    "sticky assignment",
+			workers: map[string]map[string]string{"w1:1": zoneA, "w2:1": zoneA},
+			assigns: []assign{{"t1", zoneA, "w1:1"}, {"t2", zoneA, "w2:1"}, {"t3", zoneA, "w1:1"}, {"t1", zoneA, "w1:1"}},
+		},
+		{
+			name:    "stale worker skipped",
+			workers: map[string]map[string]string{"w1:1": zoneA, "w2:1": zoneA},
+			stale:   []string{"w1:1"},
+			assigns: []assign{{"t1", zoneA, "w2:1"}, {"t2", zoneA, "w2:1"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRegistry(context.Background(), time.Hour)
+			defer r.Close()
+			for endpoint, labels := range test.workers {
+				r.Register(endpoint, labels)
+			}
+			for _, worker := range r.workers {
+				for _, endpoint := range test.stale {
+					if worker.Endpoint == endpoint {
+						worker.LastHeartbeat = time.Now().Add(-missedHeartbeats * time.Hour)
+					}
+				}
+			}
+			for _, a := range test.assigns {
+				endpoint, ok := r.Assign(a.testcase, a.selector)
+				if endpoint != a.endpoint || ok != (a.endpoint != "") {
+					t.Fatalf("Assign(%q) = %q, %v, expected %q", a.testcase, endpoint, ok, a.endpoint)
+				}
+				if ok && !r.Assigned(a.testcase, endpoint) {
+					t.Fatalf("%q is not assigned to %q", a.testcase, endpoint)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryReassign(t *testing.T) {
+	r := NewRegistry(context.Background(), time.Hour)
+	defer r.Close()
+	w1 := r.Register("w1:1", nil)
+	r.Register("w2:1", nil)
+
+	if endpoint, _ := r.Assign("t1", nil); endpoint != "w1:1" {
+		t.Fatalf("t1 assigned to %q, expected w1:1", endpoint)
+	}
+	r.Deregister(w1.ID)
+	if r.Assigned("t1", "w1:1") {
+		t.Fatal("t1 is still assigned to the deregistered worker")
+	}
+	if endpoint, _ := r.Assign("t1", nil); endpoint != "w2:1" {
+		t.Fatalf("t1 moved to %q, expected w2:1", endpoint)
+	}
+
+	// a registration replaces the previous one of its endpoint
+	w2 := r.Register("w2:1", map[string]string{"zone": "a"})
+	if workers := r.List(); len(workers) != 1 || workers[0].ID != w2.ID {
+		t.Fatalf("got workers %v, expected the latest registration of w2:1 only", workers)
+	}
+
+	r.Unassign("t1")
+	if r.Assigned("t1", "w2:1") {
+		t.Fatal("t1 is still assigned once unassigned")
+	}
+}
+
+func TestRegistryHeartbeatExpiry(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	r := NewRegistry(context.Background(), interval)
+	defer r.Close()
+	alive := r.Register("alive:1", nil)
+	silent := r.Register("silent:1", nil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if !r.Heartbeat(alive.ID) {
+			t.Fatal("the heartbeating worker was deregistered")
+		}
+		workers := r.List()
+		if len(workers) == 1 {
+			if workers[0].ID != alive.ID {
+				t.Fatalf("got workers %v, expected only the heartbeating worker", workers)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the silent worker is still registered after %s", 5*time.Second)
+		}
+		time.Sleep(interval / 4)
+	}
+	if r.Heartbeat(silent.ID) {
+		t.Fatal("the heartbeat of an expired worker was accepted")
+	}
+	if endpoint, _ := r.Assign("t1", nil); endpoint != "alive:1" {
+		t.Fatalf("t1 assigned to %q, expected alive:1", endpoint)
+	}
+}
@@ -0,0 +1,230 @@
+package task
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidSuite = errors.New("invalid suite")
+	ErrSuiteMember  = errors.New("testcase belongs to a suite")
+)
+
+// The aggregated results of a suite.
+const (
+	// SuiteResultPending is the result while no testcase failed and some
+	// are still running.
+	SuiteResultPending = "pending"
+	// SuiteResultPassed is the result once every testcase completed without
+	// an assertion failure.
+	SuiteResultPassed = "passed"
+	// SuiteResultFailed is the result as soon as a testcase failed, or
+	// completed with assertion failures.
+	SuiteResultFailed = "failed"
+)
+
+type suite struct {
+	config    *config.SuiteConfig
+	createdAt time.Time
+}
+
+// SuiteStatus aggregates the statuses of the testcases of a suite.
+type SuiteStatus struct {
+	Name string `json:"name"`
+	// State is failed as soon as a testcase failed, completed once every
+	// testcase completed, and running otherwise.
+	State            string        `json:"state"`
+	Result           string        `json:"result"`
+	CreatedAt        time.Time     `json:"created_at"`
+	Running          int           `json:"running"`
+	Completed        int           `json:"completed"`
+	Failed           int           `json:"failed"`
+	Operations       int64         `json:"operations"`
+	AssertionsPassed int64         `json:"assertions_passed"`
+	AssertionsFailed int64         `json:"assertions_failed"`
+	TestCases        []*TaskStatus `json:"testcases"`
+}
+
+// CreateSuite creates every testcase of a suite, or none of them: every
+// testcase is validated and checked against its worker before any is started.
+func (m *Manager) CreateSuite(sc *config.SuiteConfig) error {
+	if sc.Name == "" {
+		return errors.Wrap(ErrInvalidSuite, "name is required")
+	}
+	if len(sc.TestCases) == 0 {
+		return errors.Wrap(ErrInvalidSuite, "at least one testcase is required")
+	}
+	names := make(map[string]struct{}, len(sc.TestCases))
+	for _, tc := range sc.TestCases {
+		if tc == nil {
+			return errors.Wrap(ErrInvalidSuite, "null testcase")
+		}
+		if _, duplicate := names[tc.Name]; duplicate {
+			return errors.Wrapf(ErrInvalidSuite, "testcase %q is listed twice", tc.Name)
+		}
+		names[tc.Name] = struct{}{}
+	}
+	if err := m.reserveSuite(sc); err != nil {
+		return err
+	}
+
+	// the testcases are prepared without m.mu, as checking their workers may
+	// take up to describeTimeout each
+	prepared := make([]*preparedTask, 0, len(sc.TestCases))
+	var err error
+	for _, tc := range sc.TestCases {
+		var p *preparedTask
+		if p, err = m.prepareTask(tc); err != nil {
+			err = errors.Wrapf(err, "testcase %q", tc.Name)
+			break
+		}
+		prepared = append(prepared, p)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, tc := range sc.TestCases {
+		delete(m.reserved, tc.Name)
+	}
+	if err == nil {
+		if _, exist := m.suites[sc.Name]; exist {
+			err = fmt.Errorf("suite %q already exists", sc.Name)
+		}
+	}
+	if err != nil {
+		slog.Warn("Failed to create a suite", "name", sc.Name, "error", err)
+		for _, p := range prepared {
+			m.abandon(p)
+		}
+		return err
+	}
+
+	created := make([]string, 0, len(prepared))
+	for _, p := range prepared {
+		m.startTask(p)
+		m.suiteOf[p.config.Name] = sc.Name
+		created = append(created, p.config.Name)
+	}
+	m.suites[sc.Name] = &suite{config: sc, createdAt: time.Now()}
+	slog.Info("Suite created", "name", sc.Name, "testcases", created)
+	return nil
+}
+
+// reserveSuite reserves the names of the testcases of a suite, or none of
+// them when one is taken.
+func (m *Manager) reserveSuite(sc *config.SuiteConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exist := m.suites[sc.Name]; exist {
+		return fmt.Errorf("suite %q already exists", sc.Name)
+	}
+	for i, tc := range sc.TestCases {
+		if err := m.reserve(tc.Name); err != nil {
+			for _, reserved := range sc.TestCases[:i] {
+				delete(m.reserved, reserved.Name)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteSuite deletes a suite along with all of its testcases.
+func (m *Manager) DeleteSuite(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, exist := m.suites[name]
+	if !exist {
+		return fmt.Errorf("suite %q not found", name)
+	}
+	for _, tc := range s.config.TestCases {
+		delete(m.suiteOf, tc.Name)
+		if err := m.deleteTask(tc.Name); err != nil {
+			slog.Error("Failed to delete a suite testcase", "suite", name, "name", tc.Name, "error", err)
+		}
+	}
+	delete(m.suites, name)
+
+	slog.Info("Suite deleted", "name", name)
+	return nil
+}
+
+func (m *Manager) GetSuiteStatus(name string) (*SuiteStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, exist := m.suites[name]
+	if !exist {
+		return nil, false
+	}
+	return m.suiteStatus(s), true
+}
+
+// ListSuiteStatuses returns the statuses of the suites, ordered by name.
+func (m *Manager) ListSuiteStatuses() []*SuiteStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]*SuiteStatus, 0, len(m.suites))
+	for _, s := range m.suites {
+		result = append(result, m.suiteStatus(s))
+	}
+	slices.SortFunc(result, func(a, b *SuiteStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
+
+// suiteStatus aggregates the statuses of the testcases of s, with m.mu held.
+func (m *Manager) suiteStatus(s *suite) *SuiteStatus {
+	status := &SuiteStatus{
+		Name:      s.config.Name,
+		CreatedAt: s.createdAt,
+		TestCases: make([]*TaskStatus, 0, len(s.config.TestCases)),
+	}
+	for _, tc := range s.config.TestCases {
+		t, exist := m.tasks[tc.Name]
+		if !exist {
+			continue
+		}
+		ts := t.Status()
+		status.TestCases = append(status.TestCases, ts)
+		switch ts.State {
+		case StateCompleted:
+			status.Completed++
+		case StateFailed:
+			status.Failed++
+		default:
+			status.Running++
+		}
+		status.Operations += ts.Operations
+		status.AssertionsPassed += ts.AssertionsPassed
+		status.AssertionsFailed += ts.AssertionsFailed
+	}
+
+	switch {
+	case status.Failed > 0:
+		status.State = StateFailed
+	case status.Running > 0:
+		status.State = StateRunning
+	default:
+		status.State = StateCompleted
+	}
+	switch {
+	case status.Failed > 0 || status.AssertionsFailed > 0:
+		status.Result = SuiteResultFailed
+	case status.Running > 0:
+		status.Result = SuiteResultPending
+	default:
+		status.Result = SuiteResultPassed
+	}
+	return status
+}
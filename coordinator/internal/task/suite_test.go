@@ -0,0 +1,151 @@
+package task
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+)
+
+func suiteTestCase(name string, testcaseType string) *config.TestCaseConfig {
+	return &config.TestCaseConfig{
+		Name:           name,
+		Type:           testcaseType,
+		Namespace:      "default",
+		WorkerEndpoint: "127.0.0.1:1",
+	}
+}
+
+func TestCreateSuiteRejected(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		suite *config.SuiteConfig
+		// invalid is set when the suite is rejected before any testcase is prepared
+		invalid bool
+	}{
+		{
+			name:    "no name",
+			suite:   &config.SuiteConfig{TestCases: []*config.TestCaseConfig{suiteTestCase("s-1", "basic")}},
+			invalid: true,
+		},
+		{
+			name:    "no testcase",
+			suite:   &config.SuiteConfig{Name: "s"},
+			invalid: true,
+		},
+		{
+			name:    "null testcase",
+			suite:   &config.SuiteConfig{Name: "s", TestCases: []*config.TestCaseConfig{suiteTestCase("s-1", "basic"), nil}},
+			invalid: true,
+		},
+		{
+			name: "testcase listed twice",
+			suite: &config.SuiteConfig{Name: "s", TestCases: []*config.TestCaseConfig{
+				suiteTestCase("s-1", "basic"), suiteTestCase("s-1", "basic"),
+			}},
+			invalid: true,
+		},
+		{
+			name: "invalid second testcase",
+			suite: &config.SuiteConfig{Name: "s", TestCases: []*config.TestCaseConfig{
+				suiteTestCase("s-1", "basic"), suiteTestCase("s-2", "nope"),
+			}},
+		},
+		{
+			name: "second testcase without worker",
+			suite: &config.SuiteConfig{Name: "s", TestCases: []*config.TestCaseConfig{
+				suiteTestCase("s-1", "basic"), {Name: "s-2", Type: "basic"},
+			}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewManager(context.Background(), nil)
+			defer m.Close()
+
+			err := m.CreateSuite(test.suite)
+			if err == nil {
+				t.Fatal("expected the suite to be rejected")
+			}
+			if test.invalid != errors.Is(err, ErrInvalidSuite) {
+				t.Fatalf("got error %v, expected an ErrInvalidSuite: %v", err, test.invalid)
+			}
+			if !test.invalid && !errors.Is(err, ErrInvalidTestCase) {
+				t.Fatalf("got error %v, expected an ErrInvalidTestCase", err)
+			}
+			assertNothingCreated(t, m, test.suite)
+		})
+	}
+}
+
+func TestCreateSuiteTaken(t *testing.T) {
+	m := NewManager(context.Background(), nil)
+	defer m.Close()
+
+	if err := m.CreateTask(suiteTestCase("taken", "basic")); err != nil {
+		t.Fatal(err)
+	}
+	sc := &config.SuiteConfig{Name: "s", TestCases: []*config.TestCaseConfig{
+		suiteTestCase("s-1", "basic"), suiteTestCase("taken", "basic"),
+	}}
+	if err := m.CreateSuite(sc); err == nil {
+		t.Fatal("expected a suite reusing an existing testcase name to be rejected")
+	}
+	if _, exist := m.GetStatus("s-1"); exist {
+		t.Fatal("testcase s-1 was created")
+	}
+	if len(m.reserved) != 0 {
+		t.Fatalf("names still reserved: %v", m.reserved)
+	}
+	if err := m.DeleteTask("taken"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.CreateSuite(sc); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CreateSuite(&config.SuiteConfig{Name: "s", TestCases: []*config.TestCaseConfig{suiteTestCase("s-3", "basic")}}); err == nil {
+		t.Fatal("expected an existing suite to be rejected")
+	}
+	if _, exist := m.GetStatus("s-3"); exist {
+		t.Fatal("testcase s-3 of the rejected suite was created")
+	}
+	if err := m.DeleteTask("s-1"); !errors.Is(err, ErrSuiteMember) {
+		t.Fatalf("got error %v deleting a suite testcase, expected ErrSuiteMember", err)
+	}
+
+	if err := m.DeleteSuite("s"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"s-1", "taken"} {
+		if _, exist := m.GetStatus(name); exist {
+			t.Fatalf("testcase %q of the deleted suite still exists", name)
+		}
+	}
+	if _, exist := m.GetSuiteStatus("s"); exist {
+		t.Fatal("the deleted suite still exists")
+	}
+}
+
+// assertNothingCreated checks that a rejected suite left no testcase, reservation nor event behind.
+func assertNothingCreated(t *testing.T, m *Manager, sc *config.SuiteConfig) {
+	t.Helper()
+	if _, exist := m.GetSuiteStatus(sc.Name); exist {
+		t.Fatalf("suite %q was created", sc.Name)
+	}
+	if statuses := m.ListStatuses(); len(statuses) != 0 {
+		t.Fatalf("got %d testcases, expected none", len(statuses))
+	}
+	if len(m.reserved) != 0 {
+		t.Fatalf("names still reserved: %v", m.reserved)
+	}
+	for _, tc := range sc.TestCases {
+		if tc == nil {
+			continue
+		}
+		if events := m.events.Recent(tc.Name, math.MaxUint64, 10); len(events) != 0 {
+			t.Fatalf("got events %v for testcase %q, expected none", events, tc.Name)
+		}
+	}
+}
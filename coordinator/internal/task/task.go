@@ -5,22 +5,28 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/oxia-io/okk/coordinator/internal/task/generator"
+	"github.com/oxia-io/okk/coordinator/internal/config"
 	"github.com/oxia-io/okk/coordinator/internal/proto"
+	"github.com/oxia-io/okk/coordinator/internal/task/generator"
 	osserrors "github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 var (
-	ErrRetryable        = errors.New("retryable error")
-	ErrNonRetryable     = errors.New("non retryable error")
-	ErrAssertionFailure = errors.New("assertion failure")
+	ErrRetryable         = errors.New("retryable error")
+	ErrNonRetryable      = errors.New("non retryable error")
+	ErrAssertionFailure  = errors.New("assertion failure")
+	ErrOutOfSequence     = errors.New("out of sequence response")
+	ErrOperationTimeout  = errors.New("operation timeout")
+	ErrEventuallyPending = errors.New("eventual assertion pending")
+	ErrNoWorker          = errors.New("no registered worker matches the selector")
 
 	operationLatencyHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "task_operation_duration_seconds",
@@ -35,10 +41,23 @@ type Task interface {
 	Run()
 
 	Wait()
+
+	// Status returns a copy of the status of the testcase.
+	Status() *TaskStatus
 }
 
 var _ Task = &task{}
 
+// Task states reported in TaskStatus.
+const (
+	StateRunning   = "running"
+	StateCompleted = "completed"
+	StateFailed    = "failed"
+)
+
+// statusTimeout labels the operations the worker did not answer in time.
+const statusTimeout = "Timeout"
+
 type task struct {
 	sync.WaitGroup
 	ctx    context.Context
@@ -47,14 +66,39 @@ type task struct {
 
 	generator       generator.Generator
 	providerManager *ProviderManager
+	registry        *Registry
+	events          *EventBus
+	config          *config.TestCaseConfig
 	name            string
 	namespace       string
 	worker          string
-	status          *TaskStatus
+	assertionMode   proto.AssertionMode
+	// status is written by the task goroutine and read by the manager, both
+	// with statusMu held.
+	statusMu sync.Mutex
+	status   *TaskStatus
+
+	// sequence is the sequence of the last operation generated, and pending
+	// the operation in flight, which is sent again on a new stream if the
+	// current one breaks before it completes.
+	sequence int64
+	pending  *proto.Operation
+	// reconnect paces re-establishing the stream, it is reset whenever an
+	// operation completes.
+	reconnect backoff.BackOff
+	// needsResync is set after an assertion failure, until the model of the
+	// generator has been rebuilt.
+	needsResync bool
+	// capabilitiesChecked is set once the worker is known to support the generator.
+	capabilitiesChecked bool
 
 	operations       atomic.Int64
 	assertionsPassed atomic.Int64
 	assertionsFailed atomic.Int64
+	timeouts         atomic.Int64
+	retries          atomic.Int64
+	nonRetryable     atomic.Int64
+	resyncs          atomic.Int64
 }
 
 func (t *task) Close() error {
@@ -68,12 +112,28 @@ func (t *task) Run() {
 	t.Add(1)
 	go func() {
 		defer t.WaitGroup.Done()
+		defer t.providerManager.Release(t.name)
 
-		err := backoff.RetryNotify(t.run, backoff.NewExponentialBackOff(), func(err error, duration time.Duration) {
+		t.reconnect = t.newBackOff()
+		err := backoff.RetryNotify(t.run, t.reconnect, func(err error, duration time.Duration) {
 			t.logger.Error("Task running failed", "error", err, "retry-after", duration)
+			t.publish(&Event{Type: EventReconnect, Error: err.Error()})
 		})
-		if err != nil {
+		if err != nil && t.ctx.Err() == nil {
 			t.logger.Error("Task running failed", "error", err)
+			t.statusMu.Lock()
+			t.status.State = StateFailed
+			t.status.StateReason = err.Error()
+			t.statusMu.Unlock()
+			t.publish(&Event{Type: EventState, State: StateFailed, Reason: err.Error()})
+			return
+		}
+		if t.ctx.Err() == nil {
+			t.logger.Info("Task completed")
+			t.statusMu.Lock()
+			t.status.State = StateCompleted
+			t.statusMu.Unlock()
+			t.publish(&Event{Type: EventState, State: StateCompleted})
 		}
 	}()
 }
@@ -81,48 +141,100 @@ func (t *task) Run() {
 func (t *task) run() error {
 	var provider proto.OkkClient
 	var err error
-	if provider, err = t.providerManager.GetProvider(t.worker); err != nil {
+	if err = t.assignWorker(); err != nil {
 		return err
 	}
-	stream, err := provider.Execute(t.ctx)
+	if provider, err = t.providerManager.Acquire(t.name, t.worker, t.config.WorkerAuth); err != nil {
+		return err
+	}
+	if !t.capabilitiesChecked {
+		ctx, cancel := context.WithTimeout(t.ctx, describeTimeout)
+		err := checkCapabilities(ctx, provider, t.generator, t.assertionMode, t.logger)
+		cancel()
+		if err != nil {
+			if errors.Is(err, ErrUnsupportedByWorker) {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+		t.capabilitiesChecked = true
+	}
+	// the stream has its own context, so that it can be torn down when an
+	// operation misses its deadline
+	streamCtx, cancelStream := context.WithCancel(t.ctx)
+	defer cancelStream()
+	stream, err := provider.Execute(streamCtx)
 	if err != nil {
 		return err
 	}
-	bo := backoff.NewExponentialBackOff()
-
 	for {
 		select {
 		case <-t.ctx.Done():
 			t.logger.Info("Task context done")
 			return nil
 		default:
-			operation, hasNext := t.generator.Next()
-			if !hasNext {
-				return nil
+			if len(t.config.WorkerSelector) > 0 && !t.registry.Assigned(t.name, t.worker) {
+				// the pending operation is sent again to the next worker
+				return osserrors.Wrapf(ErrRetryable, "worker %s is no longer registered", t.worker)
+			}
+			if streamCtx.Err() != nil {
+				// the deadline of the last operation fired right as its response arrived
+				return osserrors.Wrap(ErrRetryable, "stream torn down by an operation deadline")
+			}
+			if t.needsResync {
+				if err := t.resync(stream, t.generator.(generator.ResyncableGenerator), streamCtx, cancelStream); err != nil {
+					return err
+				}
+				t.needsResync = false
+			}
+			operation := t.pending
+			if operation == nil {
+				var hasNext bool
+				if operation, hasNext = t.generator.Next(); !hasNext {
+					return nil
+				}
+				t.sequence++
+				operation.Sequence = t.sequence
+				if operation.Timestamp == 0 {
+					operation.Timestamp = time.Now().UnixNano()
+				}
+				t.pending = operation
 			}
+			bo := t.newBackOff()
 			err = backoff.RetryNotify(func() error {
 				startTime := time.Now()
-				if err := stream.Send(&proto.ExecuteCommand{
-					Testcase:  t.name,
-					Namespace: t.namespace,
-					Operation: operation,
-				}); err != nil {
-					if errors.Is(err, io.EOF) {
-						return backoff.Permanent(errors.New("stream closed"))
-					}
-					return err
+				opType := operationType(operation)
+				timeout := t.config.GetOpTimeout(opType)
+				timer := time.AfterFunc(timeout, cancelStream)
+				response, err := t.execute(stream, operation, t.assertionMode)
+				timer.Stop()
+				if err != nil && t.tornDown(streamCtx) {
+					operationLatencyHistogram.WithLabelValues(t.name, statusTimeout).Observe(time.Since(startTime).Seconds())
+					t.timeouts.Add(1)
+					t.syncStatus()
+					return backoff.Permanent(osserrors.Wrapf(ErrOperationTimeout,
+						"%s operation %d got no response within %s", opType, operation.Sequence, timeout))
 				}
-				response, err := stream.Recv()
 				if err != nil {
-					if errors.Is(err, io.EOF) {
-						return backoff.Permanent(errors.New("stream closed"))
-					}
 					return err
 				}
+				if response.Sequence != operation.Sequence {
+					// the stream is out of sync, so it has to be established again
+					return backoff.Permanent(osserrors.Wrapf(ErrOutOfSequence,
+						"expected sequence %d, got %d", operation.Sequence, response.Sequence))
+				}
 				status := response.Status
+				statusInfo := response.StatusInfo
+				var diff *AssertionDiff
+				if status == proto.Status_Ok && t.assertionMode == proto.AssertionMode_COORDINATOR {
+					if diff = EvaluateAssertion(operation, response); diff != nil {
+						status = proto.Status_AssertionFailure
+						statusInfo = diff.String()
+					}
+				}
 				switch status {
 				case proto.Status_Ok:
-					bo.Reset()
+					t.reconnect.Reset()
 					operationLatencyHistogram.WithLabelValues(t.name, proto.Status_Ok.String()).Observe(time.Since(startTime).Seconds())
 					t.operations.Add(1)
 					if operation.Assertion != nil {
@@ -140,56 +252,254 @@ func (t *task) run() error {
 					operationLatencyHistogram.WithLabelValues(t.name, proto.Status_NonRetryableFailure.String()).Observe(time.Since(startTime).Seconds())
 					return backoff.Permanent(osserrors.Wrap(ErrNonRetryable, response.StatusInfo))
 				case proto.Status_AssertionFailure:
-					assertion := operation.Assertion
-					timestamp := operation.GetTimestamp()
-					if assertion != nil && assertion.GetEventuallyEmpty() &&
-						time.Since(time.Unix(0, timestamp)) < 5*time.Minute {
+					if window, _, ok := eventuallyWindow(operation); ok &&
+						time.Since(time.Unix(0, operation.Timestamp)) < window {
 						operationLatencyHistogram.WithLabelValues(t.name, proto.Status_RetryableFailure.String()).Observe(time.Since(startTime).Seconds())
-						return osserrors.Wrap(ErrRetryable, response.StatusInfo)
+						return backoff.Permanent(osserrors.Wrap(ErrEventuallyPending, statusInfo))
 					}
 					operationLatencyHistogram.WithLabelValues(t.name, proto.Status_AssertionFailure.String()).Observe(time.Since(startTime).Seconds())
 					t.assertionsFailed.Add(1)
-					failureMsg := response.StatusInfo
+					failureMsg := statusInfo
+					failure := newAssertionFailure(t.worker, operation, response, diff, statusInfo)
+					t.statusMu.Lock()
 					t.status.LastFailure = &failureMsg
+					t.status.Failures = appendFailure(t.status.Failures, failure)
+					t.statusMu.Unlock()
 					t.syncStatus()
-					return backoff.Permanent(osserrors.Wrap(ErrAssertionFailure, response.StatusInfo))
+					t.publish(&Event{Type: EventAssertionFailure, Sequence: operation.Sequence, Failure: failure})
+					return backoff.Permanent(osserrors.Wrap(ErrAssertionFailure, statusInfo))
 				default:
 					operationLatencyHistogram.WithLabelValues(t.name, "Unknown").Observe(time.Since(startTime).Seconds())
 					return errors.New("unknown status")
 				}
 			}, bo, func(err error, duration time.Duration) {
-				t.logger.Error("Send command failed", "error", err, "retry-after", duration)
+				t.logger.Error("Send command failed", "sequence", operation.Sequence, "error", err, "retry-after", duration)
+				t.retries.Add(1)
+				t.syncStatus()
+				t.publish(&Event{Type: EventRetry, Sequence: operation.Sequence, Error: err.Error()})
 			})
-			if err != nil {
-				if errors.Is(err, ErrAssertionFailure) {
+			policy := t.config.GetFailurePolicy()
+			switch {
+			case err == nil:
+				t.pending = nil
+			case errors.Is(err, ErrEventuallyPending):
+				// the operation is still pending, so it is executed again
+				_, pollInterval, _ := eventuallyWindow(operation)
+				select {
+				case <-t.ctx.Done():
+				case <-time.After(pollInterval):
+				}
+			case errors.Is(err, ErrAssertionFailure):
+				if !policy.ContinueOnAssertionFailure {
 					return backoff.Permanent(err)
 				}
+				t.pending = nil
+				_, resyncable := t.generator.(generator.ResyncableGenerator)
+				t.needsResync = resyncable && policy.ResyncOnAssertionFailure
+			case errors.Is(err, ErrNonRetryable):
+				t.nonRetryable.Add(1)
+				t.syncStatus()
+				t.publish(&Event{Type: EventNonRetryable, Sequence: operation.Sequence, Error: err.Error()})
+				if policy.StopOnNonRetryable {
+					return backoff.Permanent(err)
+				}
+				// the operation is skipped, and the stream is still usable
+				t.pending = nil
+			case errors.Is(err, ErrRetryable):
+				// the stream is established again and the operation sent
+				// again, until the reconnection budget runs out too
+				return osserrors.Wrap(err, "retry budget exhausted")
+			default:
 				return err
 			}
 		}
 	}
 }
 
+// assignWorker picks the registered worker to run on, when the testcase
+// selects its worker by label, moving to another worker once the current
+// one is gone.
+func (t *task) assignWorker() error {
+	if len(t.config.WorkerSelector) == 0 {
+		return nil
+	}
+	worker, ok := t.registry.Assign(t.name, t.config.WorkerSelector)
+	if !ok {
+		return osserrors.Wrapf(ErrNoWorker, "selector %v", t.config.WorkerSelector)
+	}
+	if worker != t.worker {
+		t.logger.Info("Assigned to worker", "worker", worker, "previous", t.worker)
+		t.providerManager.Release(t.name)
+		t.worker = worker
+		t.statusMu.Lock()
+		t.status.WorkerEndpoint = worker
+		t.statusMu.Unlock()
+		t.capabilitiesChecked = false
+	}
+	return nil
+}
+
+// newBackOff returns the retry schedule of the failure policy, which stops
+// as soon as the task is closed.
+func (t *task) newBackOff() backoff.BackOff {
+	policy := t.config.GetFailurePolicy()
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = policy.GetMaxElapsed()
+	if policy.MaxRetries > 0 {
+		return backoff.WithContext(backoff.WithMaxRetries(bo, policy.MaxRetries), t.ctx)
+	}
+	return backoff.WithContext(bo, t.ctx)
+}
+
+// resync scans the whole key range of the generator, one range at a time
+// in the coordinator assertion mode so that the worker reports the records,
+// and rebuilds the model of the generator from them.
+func (t *task) resync(stream proto.Okk_ExecuteClient, rg generator.ResyncableGenerator,
+	streamCtx context.Context, cancelStream context.CancelFunc) error {
+	ranges := rg.ResyncRanges()
+	t.logger.Info("Resynchronizing the generator", "ranges", len(ranges))
+
+	var records []*proto.Record
+	for _, scanRange := range ranges {
+		rangeRecords, err := t.resyncScan(stream, scanRange, streamCtx, cancelStream)
+		if err != nil {
+			return err
+		}
+		records = append(records, rangeRecords...)
+	}
+	rg.Resync(records)
+	t.resyncs.Add(1)
+	t.syncStatus()
+	return nil
+}
+
+// resyncScan returns the records of a range, retrying the scan with the
+// failure policy.
+func (t *task) resyncScan(stream proto.Okk_ExecuteClient, scanRange generator.ScanRange,
+	streamCtx context.Context, cancelStream context.CancelFunc) ([]*proto.Record, error) {
+	t.sequence++
+	operation := &proto.Operation{
+		Sequence:  t.sequence,
+		Timestamp: time.Now().UnixNano(),
+		Operation: &proto.Operation_Scan{
+			Scan: &proto.OperationScan{
+				KeyStart: scanRange.Start,
+				KeyEnd:   scanRange.End,
+			},
+		},
+	}
+
+	var records []*proto.Record
+	err := backoff.RetryNotify(func() error {
+		timeout := t.config.GetOpTimeout(operationType(operation))
+		timer := time.AfterFunc(timeout, cancelStream)
+		response, err := t.execute(stream, operation, proto.AssertionMode_COORDINATOR)
+		timer.Stop()
+		if err != nil && t.tornDown(streamCtx) {
+			return backoff.Permanent(osserrors.Wrapf(ErrOperationTimeout,
+				"resync operation %d got no response within %s", operation.Sequence, timeout))
+		}
+		if err != nil {
+			return err
+		}
+		if response.Sequence != operation.Sequence {
+			return backoff.Permanent(osserrors.Wrapf(ErrOutOfSequence,
+				"expected sequence %d, got %d", operation.Sequence, response.Sequence))
+		}
+		if response.Status != proto.Status_Ok {
+			return osserrors.Wrap(ErrRetryable, response.StatusInfo)
+		}
+		records = response.Records
+		return nil
+	}, t.newBackOff(), func(err error, duration time.Duration) {
+		t.logger.Error("Resync failed", "sequence", operation.Sequence, "keyStart", scanRange.Start,
+			"keyEnd", scanRange.End, "error", err, "retry-after", duration)
+	})
+	return records, err
+}
+
+// tornDown tells whether an operation failed because its deadline tore down
+// the stream, rather than because the task is closed. The timer cannot tell,
+// as it may fire right as the response arrives.
+func (t *task) tornDown(streamCtx context.Context) bool {
+	return streamCtx.Err() != nil && t.ctx.Err() == nil
+}
+
+func (t *task) execute(stream proto.Okk_ExecuteClient, operation *proto.Operation,
+	assertionMode proto.AssertionMode) (*proto.ExecuteResponse, error) {
+	if err := stream.Send(&proto.ExecuteCommand{
+		Testcase:      t.name,
+		Namespace:     t.namespace,
+		Operation:     operation,
+		AssertionMode: assertionMode,
+	}); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, backoff.Permanent(errors.New("stream closed"))
+		}
+		return nil, err
+	}
+	response, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, backoff.Permanent(errors.New("stream closed"))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+// publish sends an event of the testcase, along with a copy of its status
+// for the state changes and assertion failures.
+func (t *task) publish(event *Event) {
+	event.Testcase = t.name
+	event.config = t.config
+	if event.Type == EventState || event.Type == EventAssertionFailure {
+		event.status = t.Status()
+	}
+	t.events.Publish(event)
+}
+
+func (t *task) Status() *TaskStatus {
+	t.statusMu.Lock()
+	defer t.statusMu.Unlock()
+	status := *t.status
+	status.Failures = slices.Clone(t.status.Failures)
+	return &status
+}
+
 func (t *task) syncStatus() {
+	t.statusMu.Lock()
+	defer t.statusMu.Unlock()
 	t.status.Operations = t.operations.Load()
 	t.status.AssertionsPassed = t.assertionsPassed.Load()
 	t.status.AssertionsFailed = t.assertionsFailed.Load()
+	t.status.Timeouts = t.timeouts.Load()
+	t.status.Retries = t.retries.Load()
+	t.status.NonRetryable = t.nonRetryable.Load()
+	t.status.Resyncs = t.resyncs.Load()
+	if pg, ok := t.generator.(generator.PhasedGenerator); ok {
+		t.status.Phase = string(pg.Phase())
+	}
 }
 
-func NewTask(ctx context.Context, providerManager *ProviderManager,
-	name string, namespace string, gen generator.Generator, worker string, status *TaskStatus) Task {
+func NewTask(ctx context.Context, providerManager *ProviderManager, registry *Registry, events *EventBus,
+	tc *config.TestCaseConfig, gen generator.Generator, assertionMode proto.AssertionMode, status *TaskStatus) Task {
 	currentContext, contextCancel := context.WithCancel(ctx)
-	logger := slog.With("task", name)
+	logger := slog.With("task", tc.Name)
 	t := &task{
 		ctx:             currentContext,
 		cancel:          contextCancel,
 		logger:          logger,
 		WaitGroup:       sync.WaitGroup{},
 		generator:       gen,
-		name:            name,
-		namespace:       namespace,
-		worker:          worker,
+		config:          tc,
+		name:            tc.Name,
+		namespace:       tc.Namespace,
+		worker:          status.WorkerEndpoint,
+		assertionMode:   assertionMode,
 		providerManager: providerManager,
+		registry:        registry,
+		events:          events,
 		status:          status,
 	}
 	return t
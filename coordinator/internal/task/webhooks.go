@@ -0,0 +1,349 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// The events a webhook is called on.
+const (
+	WebhookOnAssertionFailure = "assertion_failure"
+	WebhookOnCompleted        = StateCompleted
+	WebhookOnFailed           = StateFailed
+)
+
+// The parts of a testcase a webhook payload can include.
+const (
+	WebhookIncludeConfig  = "config"
+	WebhookIncludeStatus  = "status"
+	WebhookIncludeFailure = "failure"
+	WebhookIncludeHistory = "history"
+)
+
+const (
+	// defaultWebhookHistorySize is how many recent events of the testcase a
+	// payload includes by default.
+	defaultWebhookHistorySize = 20
+	// webhookQueueSize bounds how many payloads wait for delivery to a
+	// webhook, the newer ones are dropped once it is full.
+	webhookQueueSize = 100
+	// webhookRequestTimeout bounds each delivery attempt, and
+	// webhookMaxElapsed the time spent retrying a payload.
+	webhookRequestTimeout = 10 * time.Second
+	webhookMaxElapsed     = 5 * time.Minute
+)
+
+var (
+	webhookEvents   = []string{WebhookOnAssertionFailure, WebhookOnCompleted, WebhookOnFailed}
+	webhookIncludes = []string{WebhookIncludeConfig, WebhookIncludeStatus, WebhookIncludeFailure, WebhookIncludeHistory}
+)
+
+// Webhook is a URL the coordinator POSTs to when a testcase fails an
+// assertion or completes. Events and Include default to all of them.
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret signs the payloads with HMAC-SHA256, in the
+	// X-Okk-Signature-256 header. It is never listed back.
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events,omitempty"`
+	Include     []string `json:"include,omitempty"`
+	HistorySize int      `json:"historySize,omitempty"`
+}
+
+func (w *Webhook) validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidWebhook, "url: %v", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Wrapf(ErrInvalidWebhook, "url %q is not an absolute http or https URL", w.URL)
+	}
+	for _, event := range w.Events {
+		if !slices.Contains(webhookEvents, event) {
+			return errors.Wrapf(ErrInvalidWebhook, "unknown event %q, expected one of %s", event, strings.Join(webhookEvents, ", "))
+		}
+	}
+	for _, include := range w.Include {
+		if !slices.Contains(webhookIncludes, include) {
+			return errors.Wrapf(ErrInvalidWebhook, "unknown include %q, expected one of %s", include, strings.Join(webhookIncludes, ", "))
+		}
+	}
+	if w.HistorySize < 0 {
+		return errors.Wrapf(ErrInvalidWebhook, "negative historySize %d", w.HistorySize)
+	}
+	return nil
+}
+
+func (w *Webhook) calledOn(event string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+func (w *Webhook) includes(part string) bool {
+	return len(w.Include) == 0 || slices.Contains(w.Include, part)
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook.
+type WebhookPayload struct {
+	Webhook  string                 `json:"webhook"`
+	Event    string                 `json:"event"`
+	EventID  uint64                 `json:"event_id"`
+	Time     time.Time              `json:"time"`
+	Testcase string                 `json:"testcase"`
+	Reason   string                 `json:"reason,omitempty"`
+	Config   *config.TestCaseConfig `json:"config,omitempty"`
+	Status   *TaskStatus            `json:"status,omitempty"`
+	Failure  *AssertionFailure      `json:"failure,omitempty"`
+	History  []*Event               `json:"history,omitempty"`
+}
+
+type webhookDelivery struct {
+	event   string
+	eventID uint64
+	body    []byte
+}
+
+type webhookSink struct {
+	webhook *Webhook
+	queue   chan *webhookDelivery
+	cancel  context.CancelFunc
+}
+
+// WebhookDispatcher calls the webhooks on the events of the testcases. Each
+// webhook gets its payloads in order, each one retried until it is accepted
+// or webhookMaxElapsed elapses.
+type WebhookDispatcher struct {
+	sync.Mutex
+	sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+	events *EventBus
+	client *http.Client
+	sinks  map[string]*webhookSink
+}
+
+// Register validates webhook, assigns its id and starts calling it.
+func (d *WebhookDispatcher) Register(webhook *Webhook) (*Webhook, error) {
+	if err := webhook.validate(); err != nil {
+		return nil, err
+	}
+	registered := *webhook
+	registered.ID = uuid.New().String()
+
+	d.Lock()
+	defer d.Unlock()
+	ctx, cancel := context.WithCancel(d.ctx)
+	sink := &webhookSink{webhook: &registered, queue: make(chan *webhookDelivery, webhookQueueSize), cancel: cancel}
+	d.sinks[registered.ID] = sink
+	d.Add(1)
+	go d.deliverLoop(ctx, sink)
+	slog.Info("Webhook registered", "id", registered.ID, "url", registered.URL, "events", registered.Events)
+	return registered.redacted(), nil
+}
+
+// Delete stops calling the webhook id, dropping its pending payloads.
+func (d *WebhookDispatcher) Delete(id string) error {
+	d.Lock()
+	defer d.Unlock()
+	sink, ok := d.sinks[id]
+	if !ok {
+		return fmt.Errorf("webhook %q not found", id)
+	}
+	sink.cancel()
+	delete(d.sinks, id)
+	slog.Info("Webhook deleted", "id", id, "url", sink.webhook.URL)
+	return nil
+}
+
+// List returns the webhooks, without their secrets, ordered by URL.
+func (d *WebhookDispatcher) List() []*Webhook {
+	d.Lock()
+	defer d.Unlock()
+	result := make([]*Webhook, 0, len(d.sinks))
+	for _, sink := range d.sinks {
+		result = append(result, sink.webhook.redacted())
+	}
+	slices.SortFunc(result, func(a, b *Webhook) int {
+		return strings.Compare(a.URL+a.ID, b.URL+b.ID)
+	})
+	return result
+}
+
+func (w *Webhook) redacted() *Webhook {
+	listed := *w
+	listed.Secret = ""
+	return &listed
+}
+
+// dispatchLoop turns the events of the testcases into payloads. It
+// subscribes again from the last event whenever it falls behind.
+func (d *WebhookDispatcher) dispatchLoop() {
+	defer d.Done()
+	var lastID uint64
+	for d.ctx.Err() == nil {
+		events, unsubscribe := d.events.Subscribe("", lastID)
+		// the dispatcher is closed before the bus, which would end the subscription
+		stop := context.AfterFunc(d.ctx, unsubscribe)
+		for event := range events {
+			lastID = event.ID
+			d.dispatch(event)
+		}
+		stop()
+		unsubscribe()
+	}
+}
+
+func (d *WebhookDispatcher) dispatch(event *Event) {
+	var trigger string
+	switch {
+	case event.Type == EventAssertionFailure:
+		trigger = WebhookOnAssertionFailure
+	case event.Type == EventState && (event.State == StateCompleted || event.State == StateFailed):
+		trigger = event.State
+	default:
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+	for _, sink := range d.sinks {
+		if !sink.webhook.calledOn(trigger) {
+			continue
+		}
+		body, err := json.Marshal(d.payload(sink.webhook, trigger, event))
+		if err != nil {
+			slog.Error("Failed to encode the webhook payload", "id", sink.webhook.ID, "error", err)
+			continue
+		}
+		select {
+		case sink.queue <- &webhookDelivery{event: trigger, eventID: event.ID, body: body}:
+		default:
+			slog.Warn("Webhook queue is full, dropping the payload", "id", sink.webhook.ID, "url", sink.webhook.URL,
+				"event", trigger, "testcase", event.Testcase)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) payload(webhook *Webhook, trigger string, event *Event) *WebhookPayload {
+	payload := &WebhookPayload{
+		Webhook:  webhook.ID,
+		Event:    trigger,
+		EventID:  event.ID,
+		Time:     event.Time,
+		Testcase: event.Testcase,
+		Reason:   event.Reason,
+	}
+	if webhook.includes(WebhookIncludeConfig) {
+		payload.Config = event.config
+	}
+	if webhook.includes(WebhookIncludeStatus) {
+		payload.Status = event.status
+	}
+	if webhook.includes(WebhookIncludeFailure) {
+		payload.Failure = event.Failure
+	}
+	if webhook.includes(WebhookIncludeHistory) {
+		historySize := webhook.HistorySize
+		if historySize == 0 {
+			historySize = defaultWebhookHistorySize
+		}
+		payload.History = d.events.Recent(event.Testcase, event.ID, historySize)
+	}
+	return payload
+}
+
+func (d *WebhookDispatcher) deliverLoop(ctx context.Context, sink *webhookSink) {
+	defer d.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery := <-sink.queue:
+			bo := backoff.NewExponentialBackOff()
+			bo.MaxElapsedTime = webhookMaxElapsed
+			err := backoff.RetryNotify(func() error {
+				return d.deliver(ctx, sink.webhook, delivery)
+			}, backoff.WithContext(bo, ctx), func(err error, duration time.Duration) {
+				slog.Warn("Webhook delivery failed", "id", sink.webhook.ID, "url", sink.webhook.URL,
+					"event_id", delivery.eventID, "error", err, "retry-after", duration)
+			})
+			if err != nil && ctx.Err() == nil {
+				slog.Error("Webhook delivery abandoned", "id", sink.webhook.ID, "url", sink.webhook.URL,
+					"event_id", delivery.eventID, "error", err)
+			}
+		}
+	}
+}
+
+// deliver POSTs a payload once. Network errors, 429 and 5xx responses are
+// retried, other non-2xx responses are not.
+func (d *WebhookDispatcher) deliver(ctx context.Context, webhook *Webhook, delivery *webhookDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "okk-coordinator")
+	req.Header.Set("X-Okk-Event", delivery.event)
+	req.Header.Set("X-Okk-Delivery", strconv.FormatUint(delivery.eventID, 10))
+	if webhook.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(webhook.Secret))
+		mac.Write(delivery.body)
+		req.Header.Set("X-Okk-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	default:
+		return backoff.Permanent(fmt.Errorf("webhook responded %s", resp.Status))
+	}
+}
+
+func (d *WebhookDispatcher) Close() error {
+	d.cancel()
+	d.Wait()
+	return nil
+}
+
+func newWebhookDispatcher(ctx context.Context, events *EventBus) *WebhookDispatcher {
+	currentContext, currentContextCancel := context.WithCancel(ctx)
+	d := &WebhookDispatcher{
+		ctx:    currentContext,
+		cancel: currentContextCancel,
+		events: events,
+		client: &http.Client{},
+		sinks:  make(map[string]*webhookSink),
+	}
+	d.Add(1)
+	go d.dispatchLoop()
+	return d
+}
@@ -0,0 +1,97 @@
+package task
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"strings"
+
+	"github.com/oxia-io/okk/coordinator/internal/config"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var ErrInvalidWorkerAuth = errors.New("invalid worker auth")
+
+// ValidateWorkerAuth checks auth is consistent and its files can be read, so
+// that a misconfiguration is reported when the testcase is created rather
+// than on every attempt to connect.
+func ValidateWorkerAuth(auth *config.WorkerAuth) error {
+	if auth == nil {
+		return nil
+	}
+	if (auth.CertFile == "") != (auth.KeyFile == "") {
+		return errors.Wrap(ErrInvalidWorkerAuth, "certFile and keyFile must be set together")
+	}
+	if auth.TokenFile != "" && !auth.TLSEnabled() {
+		return errors.Wrap(ErrInvalidWorkerAuth, "a bearer token requires TLS")
+	}
+	if _, err := newTransportCredentials(auth); err != nil {
+		return err
+	}
+	if auth.TokenFile != "" {
+		if _, err := readToken(auth.TokenFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTransportCredentials(auth *config.WorkerAuth) (credentials.TransportCredentials, error) {
+	if auth == nil || !auth.TLSEnabled() {
+		return insecure.NewCredentials(), nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: auth.ServerName,
+	}
+	if auth.CAFile != "" {
+		pem, err := os.ReadFile(auth.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidWorkerAuth, "failed to read caFile: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.Wrapf(ErrInvalidWorkerAuth, "no certificate found in caFile %s", auth.CAFile)
+		}
+	}
+	if auth.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(auth.CertFile, auth.KeyFile)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidWorkerAuth, "failed to load the client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func readToken(tokenFile string) (string, error) {
+	content, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", errors.Wrapf(ErrInvalidWorkerAuth, "failed to read tokenFile: %v", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", errors.Wrapf(ErrInvalidWorkerAuth, "tokenFile %s is empty", tokenFile)
+	}
+	return token, nil
+}
+
+// tokenCredentials sends the bearer token read from tokenFile with every RPC.
+type tokenCredentials struct {
+	tokenFile string
+}
+
+func (t *tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	token, err := readToken(t.tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -10,11 +10,18 @@ import io.oxia.client.api.exceptions.KeyAlreadyExistsException;
 import io.oxia.client.api.exceptions.UnexpectedVersionIdException;
 import io.oxia.client.api.PutResult;
 import io.oxia.client.api.options.GetOption;
+import io.oxia.client.api.options.GetSequenceUpdatesOption;
+import io.oxia.client.api.options.ListOption;
 import io.oxia.client.api.options.PutOption;
 import io.oxia.client.api.options.defs.OptionEphemeral;
+import io.oxia.client.api.RangeScanConsumer;
 import io.oxia.okk.proto.v1.Assertion;
+import io.oxia.okk.proto.v1.AssertionFailureDetail;
+import io.oxia.okk.proto.v1.AssertionMode;
+import io.oxia.okk.proto.v1.DescribeResponse;
 import io.oxia.okk.proto.v1.ExecuteCommand;
 import io.oxia.okk.proto.v1.ExecuteResponse;
+import io.oxia.okk.proto.v1.ExpectedError;
 import io.oxia.okk.proto.v1.KeyComparisonType;
 import io.oxia.okk.proto.v1.NotificationType;
 import io.oxia.okk.proto.v1.Operation;
@@ -24,22 +31,47 @@ import io.oxia.okk.proto.v1.OperationGet;
 import io.oxia.okk.proto.v1.OperationList;
 import io.oxia.okk.proto.v1.OperationPut;
 import io.oxia.okk.proto.v1.OperationScan;
+import io.oxia.okk.proto.v1.OperationSequenceUpdates;
 import io.oxia.okk.proto.v1.Precondition;
 import io.oxia.okk.proto.v1.Record;
+import io.oxia.okk.proto.v1.SecondaryIndex;
 import io.oxia.okk.proto.v1.Status;
+import io.oxia.okk.proto.v1.SubscriptionAction;
+import com.google.protobuf.ByteString;
+import io.grpc.StatusRuntimeException;
 import lombok.SneakyThrows;
 import lombok.extern.slf4j.Slf4j;
+import java.io.Closeable;
+import java.util.ArrayList;
 import java.util.Arrays;
 import java.util.HashSet;
 import java.util.List;
+import java.util.Map;
 import java.util.Set;
 import java.util.concurrent.BlockingDeque;
+import java.util.concurrent.CompletableFuture;
+import java.util.concurrent.ConcurrentHashMap;
 import java.util.concurrent.LinkedBlockingDeque;
 import java.util.concurrent.TimeUnit;
+import java.util.zip.CRC32C;
 
 @Slf4j
 public class OxiaEngine implements Engine {
 
+    private static final String WORKER_ID = System.getenv().getOrDefault("HOSTNAME", "unknown");
+
+    private static final List<String> OPERATIONS = List.of(
+            "put", "delete", "get", "list", "scan", "sessionRestart", "deleteRange", "sequenceUpdates",
+            "put.secondaryIndexes", "get.useIndex", "list.useIndex");
+    private static final List<String> ASSERTIONS = List.of(
+            "put.records", "put.notification", "put.versionConflict", "put.expectedError",
+            "get.records", "get.emptyRecords", "get.expectedError",
+            "list.records", "list.emptyRecords", "list.expectedError",
+            "scan.records",
+            "delete.notification", "delete.expectedError",
+            "deleteRange.notification", "deleteRange.expectedError",
+            "sequenceUpdates.sequenceUpdates");
+
     private Options options;
     private AsyncOxiaClient oxiaClient;
 
@@ -47,6 +79,11 @@ public class OxiaEngine implements Engine {
     private boolean watchedNotification;
     private BlockingDeque<Notification> notifications;
 
+    private record SequenceUpdatesSubscription(Closeable handle, BlockingDeque<String> updates) {
+    }
+
+    private final Map<String, SequenceUpdatesSubscription> sequenceUpdates = new ConcurrentHashMap<>();
+
     @Override
     public void init() {
         options = Options.fromEnv();
@@ -79,7 +116,7 @@ public class OxiaEngine implements Engine {
 
 
     @SneakyThrows
-    private ExecuteResponse processPut(Operation operation) {
+    private ExecuteResponse processPut(Operation operation, boolean observe) {
         if (operation.hasPrecondition()) {
             final Precondition precondition = operation.getPrecondition();
             if (precondition.getBypassIfAssertKeyExist()) {
@@ -93,8 +130,15 @@ public class OxiaEngine implements Engine {
                             // the key might be exists
                             final String getKey = result.key();
                             final byte[] getValue = result.value();
+                            if (observe) {
+                                log.info("[Put][{}] The precondition BypassIfAssertKeyExist found the key.", operation.getSequence());
+                                return ExecuteResponse.newBuilder()
+                                        .setStatus(Status.Ok)
+                                        .addRecords(toRecord(result))
+                                        .build();
+                            }
                             if (getKey.equals(expectRecord.getKey())
-                                    && Arrays.equals(getValue, expectRecord.getValue().toByteArray())) {
+                                    && matchesValue(expectRecord, getValue)) {
                                 log.info("[Put][{}] The precondition BypassIfAssertKeyExist is met.", operation.getSequence());
                                 return ExecuteResponse.newBuilder()
                                         .setStatus(Status.Ok)
@@ -102,10 +146,7 @@ public class OxiaEngine implements Engine {
                             } else {
                                 log.warn("[Put][{}] Assertion failure, mismatched key or value. expect: key={} vlaue={} actual: key={} value={}",
                                         operation.getSequence(), expectRecord.getKey(), expectRecord.getValue(), getKey, getValue);
-                                return ExecuteResponse.newBuilder()
-                                        .setStatus(Status.AssertionFailure)
-                                        .setStatusInfo("mismatched key or value.")
-                                        .build();
+                                return assertionFailure(operation, "mismatched key or value.", List.of(toRecord(result)));
                             }
                         }
                     }
@@ -117,24 +158,7 @@ public class OxiaEngine implements Engine {
         }
 
         final OperationPut put = operation.getPut();
-        final var optionSet = new HashSet<PutOption>();
-        if (put.hasPartitionKey()) {
-            optionSet.add(PutOption.PartitionKey(put.getPartitionKey()));
-        }
-        if (put.getSequenceKeyDeltaCount() > 0) {
-            optionSet.add(PutOption.SequenceKeysDeltas(put.getSequenceKeyDeltaList()));
-        }
-        if (put.getEphemeral()) {
-            optionSet.add(OptionEphemeral.AsEphemeralRecord);
-        }
-        if (put.hasExpectedVersionId()) {
-            long expectedVersion = put.getExpectedVersionId();
-            if (expectedVersion == -1) {
-                optionSet.add(PutOption.IfRecordDoesNotExist);
-            } else {
-                optionSet.add(PutOption.IfVersionIdEquals(expectedVersion));
-            }
-        }
+        final Set<PutOption> optionSet = putOptions(put);
 
         // Check if this operation expects a version conflict
         boolean expectConflict = operation.hasAssertion()
@@ -151,6 +175,12 @@ public class OxiaEngine implements Engine {
             }
             boolean isVersionConflict = cause instanceof KeyAlreadyExistsException
                     || cause instanceof UnexpectedVersionIdException;
+            if (observe && isVersionConflict) {
+                return ExecuteResponse.newBuilder()
+                        .setStatus(Status.Ok)
+                        .setVersionConflict(true)
+                        .build();
+            }
             if (expectConflict && isVersionConflict) {
                 log.info("[Put][{}] Expected version conflict occurred as expected: {}",
                         operation.getSequence(), cause.getMessage());
@@ -161,12 +191,9 @@ public class OxiaEngine implements Engine {
             throw ex;
         }
 
-        if (expectConflict) {
+        if (expectConflict && !observe) {
             log.warn("[Put][{}] Expected version conflict but put succeeded", operation.getSequence());
-            return ExecuteResponse.newBuilder()
-                    .setStatus(Status.AssertionFailure)
-                    .setStatusInfo("expected version conflict but put succeeded")
-                    .build();
+            return assertionFailure(operation, "expected version conflict but put succeeded");
         }
 
         // Return version_id from successful put
@@ -174,6 +201,14 @@ public class OxiaEngine implements Engine {
         if (result.version() != null) {
             responseBuilder.setVersionId(result.version().versionId());
         }
+        if (observe) {
+            if (put.hasExpectedVersionId()) {
+                responseBuilder.setVersionConflict(false);
+            }
+            responseBuilder.addRecords(Record.newBuilder().setKey(result.key()).build());
+            observeNotification(operation, responseBuilder);
+            return responseBuilder.build();
+        }
 
         if (operation.hasAssertion()) {
             final Assertion assertion = operation.getAssertion();
@@ -185,10 +220,7 @@ public class OxiaEngine implements Engine {
                 if (!putKey.equals(expectKey)) {
                     log.warn("[Put][{}] Assertion failure, mismatched key. expect: key={} actual: key={}",
                             operation.getSequence(), expectRecord.getKey(), putKey);
-                    return ExecuteResponse.newBuilder()
-                            .setStatus(Status.AssertionFailure)
-                            .setStatusInfo("mismatched key.")
-                            .build();
+                    return assertionFailure(operation, "mismatched key.", List.of(Record.newBuilder().setKey(putKey).build()));
                 }
             }
             if (assertion.hasNotification()) {
@@ -198,27 +230,18 @@ public class OxiaEngine implements Engine {
                 // Derive key prefix to filter out notifications from other test cases
                 final String keyPrefix = expectKey != null ? expectKey.substring(0, expectKey.indexOf('/', 1) + 1) : null;
 
-                final var actualNotification = pollMatchingNotification(keyPrefix, 3, TimeUnit.MINUTES);
+                final var actualNotification = pollMatchingNotification(keyPrefix, notificationTimeoutMillis(assertion), TimeUnit.MILLISECONDS);
                 if (actualNotification instanceof Notification.KeyCreated an) {
                     if (expectType != NotificationType.KEY_CREATED || !expectKey.equals(an.key())) {
-                        return ExecuteResponse.newBuilder()
-                                .setStatus(Status.AssertionFailure)
-                                .setStatusInfo("mismatched notification.")
-                                .build();
+                        return assertionFailure(operation, "mismatched notification.", actualNotification);
                     }
 
                 } else if (actualNotification instanceof Notification.KeyModified an) {
                     if (expectType != NotificationType.KEY_MODIFIED || !expectKey.equals(an.key())) {
-                        return ExecuteResponse.newBuilder()
-                                .setStatus(Status.AssertionFailure)
-                                .setStatusInfo("mismatched notification.")
-                                .build();
+                        return assertionFailure(operation, "mismatched notification.", actualNotification);
                     }
                 } else {
-                    return ExecuteResponse.newBuilder()
-                            .setStatus(Status.AssertionFailure)
-                            .setStatusInfo("mismatched notification.")
-                            .build();
+                    return assertionFailure(operation, "mismatched notification.", actualNotification);
                 }
             }
         }
@@ -226,44 +249,59 @@ public class OxiaEngine implements Engine {
         return responseBuilder.build();
     }
 
-    private ExecuteResponse processScan(Operation operation) {
+    private ExecuteResponse processScan(Operation operation, boolean observe) {
         final OperationScan scanOp = operation.getScan();
+        final CompletableFuture<Void> future = new CompletableFuture<>();
+        final List<GetResult> results = new ArrayList<>();
+        oxiaClient.rangeScan(scanOp.getKeyStart(), scanOp.getKeyEnd(), new RangeScanConsumer() {
+            @Override
+            public void onNext(GetResult result) {
+                results.add(result);
+            }
+
+            @Override
+            public void onError(Throwable throwable) {
+                future.completeExceptionally(throwable);
+            }
 
-//        final CompletableFuture<Void> future = new CompletableFuture<>();
-//        final List<GetResult> results = new ArrayList<>();
-//        oxiaClient.rangeScan(scanOp.getKeyStart(), scanOp.getKeyEnd(), new RangeScanConsumer() {
-//            @Override
-//            public void onNext(GetResult result) {
-//                results.add(result);
-//            }
-//
-//            @Override
-//            public void onError(Throwable throwable) {
-//                future.completeExceptionally(throwable);
-//            }
-//
-//            @Override
-//            public void onCompleted() {
-//                future.complete(null);
-//            }
-//        });
-//        future.join();
-//
-//        if (operation.hasAssertion()) {
-//            final Assertion assertion = operation.getAssertion();
-//            if (!assertion.getRecordsList().isEmpty()) {
-//                log.info("[Scan][{}] Check the assertion records", operation.getSequence());
-//
-//                log.info("[Scan][{}] Assertion successful", operation.getSequence());
-//            }
-//        }
+            @Override
+            public void onCompleted() {
+                future.complete(null);
+            }
+        });
+        future.join();
 
+        if (observe) {
+            final var responseBuilder = ExecuteResponse.newBuilder().setStatus(Status.Ok);
+            for (GetResult result : results) {
+                responseBuilder.addRecords(toRecord(result));
+            }
+            return responseBuilder.build();
+        }
+
+        if (operation.hasAssertion()) {
+            log.info("[Scan][{}] Check the assertion records", operation.getSequence());
+            final List<Record> expectRecords = operation.getAssertion().getRecordsList();
+            boolean matches = expectRecords.size() == results.size();
+            for (int i = 0; matches && i < results.size(); i++) {
+                final Record expectRecord = expectRecords.get(i);
+                final GetResult result = results.get(i);
+                matches = expectRecord.getKey().equals(result.key()) && matchesValue(expectRecord, result.value());
+            }
+            if (!matches) {
+                log.warn("[Scan][{}] Assertion failure, expect {} records, actual {} records",
+                        operation.getSequence(), expectRecords.size(), results.size());
+                return assertionFailure(operation, "mismatched records, expect %d records, actual %d records"
+                        .formatted(expectRecords.size(), results.size()), results.stream().map(OxiaEngine::toRecord).toList());
+            }
+            log.info("[Scan][{}] Assertion successful", operation.getSequence());
+        }
         return ExecuteResponse.newBuilder()
                 .setStatus(Status.Ok)
                 .build();
     }
 
-    private ExecuteResponse processGet(String testcase, Operation operation) {
+    private ExecuteResponse processGet(String testcase, Operation operation, boolean observe) {
         final OperationGet get = operation.getGet();
         final String key = get.getKey();
         final KeyComparisonType comparisonType = get.getComparisonType();
@@ -278,6 +316,9 @@ public class OxiaEngine implements Engine {
             default -> {
             }
         }
+        if (get.hasUseIndex()) {
+            getOptions.add(GetOption.UseIndex(get.getUseIndex()));
+        }
         GetResult getResult = oxiaClient.get(key, getOptions).join();
 
         // avoid expose internal keys
@@ -289,6 +330,14 @@ public class OxiaEngine implements Engine {
             }
         }
 
+        if (observe) {
+            final var responseBuilder = ExecuteResponse.newBuilder().setStatus(Status.Ok);
+            if (getResult != null) {
+                responseBuilder.addRecords(toRecord(getResult));
+            }
+            return responseBuilder.build();
+        }
+
         if (operation.hasAssertion()) {
             final Assertion assertion = operation.getAssertion();
             if (assertion.hasEmptyRecords() && assertion.getEmptyRecords()) {
@@ -296,10 +345,8 @@ public class OxiaEngine implements Engine {
                 if (getResult != null) {
                     log.warn("[Get][{}] Assertion failure, expect empty record. actual: key={} value={}",
                             operation.getSequence(), getResult.key(), getResult.value());
-                    return ExecuteResponse.newBuilder()
-                            .setStatus(Status.AssertionFailure)
-                            .setStatusInfo("mismatch key or value")
-                            .build();
+                    return assertionFailure(operation, "mismatch key or value", getResult == null
+                            ? List.of() : List.of(toRecord(getResult)));
                 }
                 log.info("[Get][{}] Assertion successful", operation.getSequence());
             }
@@ -309,13 +356,11 @@ public class OxiaEngine implements Engine {
                 final byte[] actualValue = getResult.value();
                 log.info("[Get][{}] Check the assertion records", operation.getSequence());
                 final Record expectRecord = recordsList.get(0);
-                if (!expectRecord.getKey().equals(actualKey) || !Arrays.equals(expectRecord.getValue().toByteArray(), actualValue)) {
+                if (!expectRecord.getKey().equals(actualKey) || !matchesValue(expectRecord, actualValue)) {
                     log.warn("[Get][{}] Assertion failure, mismatched key or value. expect: key={} value={} actual: key={} value={}",
                             operation.getSequence(), expectRecord.getKey(), expectRecord.getValue(), actualKey, actualValue);
-                    return ExecuteResponse.newBuilder()
-                            .setStatus(Status.AssertionFailure)
-                            .setStatusInfo("mismatch key or value")
-                            .build();
+                    return assertionFailure(operation, "mismatch key or value", getResult == null
+                            ? List.of() : List.of(toRecord(getResult)));
                 }
                 log.info("[Get][{}] Assertion successful", operation.getSequence());
             }
@@ -325,9 +370,18 @@ public class OxiaEngine implements Engine {
                 .build();
     }
 
-    private ExecuteResponse processList(Operation operation) {
+    private ExecuteResponse processList(Operation operation, boolean observe) {
         final OperationList listOp = operation.getList();
-        final List<String> actualKeys = oxiaClient.list(listOp.getKeyStart(), listOp.getKeyEnd()).join();
+        final Set<ListOption> listOptions = new HashSet<>();
+        if (listOp.hasUseIndex()) {
+            listOptions.add(ListOption.UseIndex(listOp.getUseIndex()));
+        }
+        final List<String> actualKeys = oxiaClient.list(listOp.getKeyStart(), listOp.getKeyEnd(), listOptions).join();
+
+        if (observe) {
+            final var responseBuilder = ExecuteResponse.newBuilder().setStatus(Status.Ok);
+            return responseBuilder.addAllRecords(toKeyRecords(actualKeys)).build();
+        }
 
         if (operation.hasAssertion()) {
             final Assertion assertion = operation.getAssertion();
@@ -335,10 +389,8 @@ public class OxiaEngine implements Engine {
                 log.info("[List][{}] Check the empty assertion", operation.getSequence());
                 if (!actualKeys.isEmpty()) {
                     log.warn("[List][{}] Assertion failure", operation.getSequence());
-                    return ExecuteResponse.newBuilder()
-                            .setStatus(Status.AssertionFailure)
-                            .setStatusInfo("expect empty, but the actual is %s ".formatted(String.join(",", actualKeys)))
-                            .build();
+                    return assertionFailure(operation, "expect empty, but the actual is %s ".formatted(String.join(",", actualKeys)),
+                            toKeyRecords(actualKeys));
                 }
                 log.info("[List][{}] Assertion successful", operation.getSequence());
             }
@@ -347,13 +399,11 @@ public class OxiaEngine implements Engine {
                 final List<String> expectKeys = assertion.getRecordsList().stream().map(Record::getKey).toList();
                 if (!actualKeys.equals(expectKeys)) {
                     log.warn("[List][{}] Assertion failure", operation.getSequence());
-                    return ExecuteResponse.newBuilder()
-                            .setStatus(Status.AssertionFailure)
-                            .setStatusInfo("different keys expect %s, but the actual is %s ".formatted(
+                    return assertionFailure(operation, "different keys expect %s, but the actual is %s ".formatted(
                                     String.join(",", expectKeys),
                                     String.join(",", actualKeys)
-                            ))
-                            .build();
+                            ),
+                            toKeyRecords(actualKeys));
                 }
                 log.info("[List][{}] Assertion successful", operation.getSequence());
             }
@@ -363,6 +413,127 @@ public class OxiaEngine implements Engine {
                 .build();
     }
 
+    private static Set<PutOption> putOptions(OperationPut put) {
+        final var optionSet = new HashSet<PutOption>();
+        if (put.hasPartitionKey()) {
+            optionSet.add(PutOption.PartitionKey(put.getPartitionKey()));
+        }
+        if (put.getSequenceKeyDeltaCount() > 0) {
+            optionSet.add(PutOption.SequenceKeysDeltas(put.getSequenceKeyDeltaList()));
+        }
+        if (put.getEphemeral()) {
+            optionSet.add(OptionEphemeral.AsEphemeralRecord);
+        }
+        for (SecondaryIndex secondaryIndex : put.getSecondaryIndexesList()) {
+            optionSet.add(PutOption.SecondaryIndex(secondaryIndex.getIndexName(), secondaryIndex.getSecondaryKey()));
+        }
+        if (put.hasExpectedVersionId()) {
+            long expectedVersion = put.getExpectedVersionId();
+            if (expectedVersion == -1) {
+                optionSet.add(PutOption.IfRecordDoesNotExist);
+            } else {
+                optionSet.add(PutOption.IfVersionIdEquals(expectedVersion));
+            }
+        }
+        return optionSet;
+    }
+
+    /**
+     * Execute an operation whose assertion expects an error, and compare the error it failed with against
+     * the expected one.
+     */
+    @SneakyThrows
+    private ExecuteResponse processExpectedError(Operation operation, boolean observe) {
+        final ExpectedError expectedError = operation.getAssertion().getExpectedError();
+        ExpectedError actualError = ExpectedError.NO_ERROR;
+        try {
+            switch (operation.getOperationCase()) {
+                case PUT -> {
+                    final OperationPut put = operation.getPut();
+                    oxiaClient.put(put.getKey(), put.getValue().toByteArray(), putOptions(put)).join();
+                }
+                case GET -> {
+                    if (oxiaClient.get(operation.getGet().getKey()).join() == null) {
+                        actualError = ExpectedError.KEY_NOT_FOUND;
+                    }
+                }
+                case DELETE -> {
+                    if (!oxiaClient.delete(operation.getDelete().getKey()).join()) {
+                        actualError = ExpectedError.KEY_NOT_FOUND;
+                    }
+                }
+                case LIST -> {
+                    final OperationList listOp = operation.getList();
+                    oxiaClient.list(listOp.getKeyStart(), listOp.getKeyEnd(), Set.of()).join();
+                }
+                case DELETE_RANGE -> {
+                    final OperationDeleteRange deleteRange = operation.getDeleteRange();
+                    oxiaClient.deleteRange(deleteRange.getKeyStart(), deleteRange.getKeyEnd()).join();
+                }
+                default -> {
+                    log.error("Unsupported operation for an expected error. operation={}", operation);
+                    return ExecuteResponse.newBuilder()
+                            .setStatus(Status.NonRetryableFailure)
+                            .setStatusInfo("Unsupported Operation for an expected error.")
+                            .build();
+                }
+            }
+        } catch (Exception ex) {
+            actualError = classifyError(ex);
+            if (actualError == null) {
+                throw ex;
+            }
+        }
+
+        if (observe) {
+            return ExecuteResponse.newBuilder()
+                    .setStatus(Status.Ok)
+                    .setError(actualError)
+                    .build();
+        }
+        if (actualError != expectedError) {
+            log.warn("[ExpectedError][{}] Assertion failure, expect: {} actual: {}",
+                    operation.getSequence(), expectedError, actualError);
+            final ExecuteResponse failure = assertionFailure(operation,
+                    "expected error %s, but the actual is %s".formatted(expectedError, actualError));
+            return failure.toBuilder()
+                    .setFailureDetail(failure.getFailureDetail().toBuilder().setActualError(actualError))
+                    .build();
+        }
+        log.info("[ExpectedError][{}] Failed with {} as expected", operation.getSequence(), actualError);
+        return ExecuteResponse.newBuilder()
+                .setStatus(Status.Ok)
+                .build();
+    }
+
+    /**
+     * Map an error thrown by the client to the catalog, or null when it is not one of the expected errors.
+     */
+    private static ExpectedError classifyError(Throwable ex) {
+        Throwable cause = ex;
+        while (cause.getCause() != null) {
+            cause = cause.getCause();
+        }
+        if (cause instanceof KeyAlreadyExistsException || cause instanceof UnexpectedVersionIdException) {
+            return ExpectedError.VERSION_CONFLICT;
+        }
+        // the exception is not part of every client release
+        if (cause.getClass().getSimpleName().equals("SessionDoesNotExistException")) {
+            return ExpectedError.SESSION_EXPIRED;
+        }
+        if (cause instanceof IllegalArgumentException) {
+            return ExpectedError.INVALID_RANGE;
+        }
+        if (cause instanceof StatusRuntimeException sre) {
+            return switch (sre.getStatus().getCode()) {
+                case RESOURCE_EXHAUSTED -> ExpectedError.REQUEST_TOO_LARGE;
+                case INVALID_ARGUMENT -> ExpectedError.INVALID_RANGE;
+                default -> null;
+            };
+        }
+        return null;
+    }
+
     @SneakyThrows
     private ExecuteResponse processSessionRestart(Operation __) {
         oxiaClient.close();
@@ -373,7 +544,96 @@ public class OxiaEngine implements Engine {
     }
 
     @SneakyThrows
-    private ExecuteResponse processDelete(Operation operation) {
+    private ExecuteResponse processSequenceUpdates(Operation operation, boolean observe) {
+        final OperationSequenceUpdates sequenceUpdatesOp = operation.getSequenceUpdates();
+        final String subscriptionKey = sequenceUpdatesOp.getPartitionKey() + "/" + sequenceUpdatesOp.getKey();
+
+        // subscribe and unsubscribe are both idempotent, so a retried command replaces the previous subscription
+        final SequenceUpdatesSubscription previous = sequenceUpdates.remove(subscriptionKey);
+        if (previous != null) {
+            previous.handle().close();
+        }
+        if (sequenceUpdatesOp.getAction() == SubscriptionAction.SUBSCRIBE) {
+            final BlockingDeque<String> updates = new LinkedBlockingDeque<>();
+            final Closeable handle = oxiaClient.getSequenceUpdates(sequenceUpdatesOp.getKey(), updates::add,
+                    Set.of(GetSequenceUpdatesOption.PartitionKey(sequenceUpdatesOp.getPartitionKey())));
+            sequenceUpdates.put(subscriptionKey, new SequenceUpdatesSubscription(handle, updates));
+            log.info("[SequenceUpdates][{}] Subscribed. key={}", operation.getSequence(), subscriptionKey);
+            return ExecuteResponse.newBuilder()
+                    .setStatus(Status.Ok)
+                    .build();
+        }
+
+        if (!operation.hasAssertion() || operation.getAssertion().getSequenceUpdatesList().isEmpty()) {
+            return ExecuteResponse.newBuilder()
+                    .setStatus(Status.Ok)
+                    .build();
+        }
+        if (previous == null) {
+            if (observe) {
+                // the coordinator finds the expected updates missing
+                return ExecuteResponse.newBuilder()
+                        .setStatus(Status.Ok)
+                        .build();
+            }
+            log.warn("[SequenceUpdates][{}] Assertion failure, no subscription. key={}", operation.getSequence(), subscriptionKey);
+            return assertionFailure(operation, "no sequence updates subscription for %s".formatted(subscriptionKey));
+        }
+
+        final List<String> expectKeys = operation.getAssertion().getSequenceUpdatesList();
+        final String firstKey = expectKeys.get(0);
+        final String lastKey = expectKeys.get(expectKeys.size() - 1);
+        final List<String> actualKeys = new ArrayList<>();
+        final long deadline = System.nanoTime() + TimeUnit.MILLISECONDS.toNanos(sequenceUpdatesTimeoutMillis(operation.getAssertion()));
+        while (actualKeys.isEmpty() || !actualKeys.get(actualKeys.size() - 1).equals(lastKey)) {
+            final long remaining = deadline - System.nanoTime();
+            if (remaining <= 0) {
+                break;
+            }
+            final String update = previous.updates().poll(remaining, TimeUnit.NANOSECONDS);
+            if (update == null) {
+                break;
+            }
+            // the subscription reports the latest key when it starts, which may predate the batch
+            if (update.compareTo(firstKey) >= 0) {
+                actualKeys.add(update);
+            }
+        }
+
+        if (observe) {
+            return ExecuteResponse.newBuilder()
+                    .setStatus(Status.Ok)
+                    .addAllSequenceUpdates(actualKeys)
+                    .build();
+        }
+
+        // Oxia may coalesce updates, so the observed keys must be an in-order
+        // subsequence of the produced keys that ends with the last one
+        int expectIndex = 0;
+        for (String actualKey : actualKeys) {
+            while (expectIndex < expectKeys.size() && !expectKeys.get(expectIndex).equals(actualKey)) {
+                expectIndex++;
+            }
+            if (expectIndex == expectKeys.size()) {
+                log.warn("[SequenceUpdates][{}] Assertion failure, unexpected or out of order key {}. actual: {}",
+                        operation.getSequence(), actualKey, actualKeys);
+                return assertionFailure(operation, "unexpected or out of order sequence update %s".formatted(actualKey));
+            }
+            expectIndex++;
+        }
+        if (actualKeys.isEmpty() || !actualKeys.get(actualKeys.size() - 1).equals(lastKey)) {
+            log.warn("[SequenceUpdates][{}] Assertion failure, missing the latest key {}. actual: {}",
+                    operation.getSequence(), lastKey, actualKeys);
+            return assertionFailure(operation, "missing sequence update %s".formatted(lastKey));
+        }
+        log.info("[SequenceUpdates][{}] Assertion successful", operation.getSequence());
+        return ExecuteResponse.newBuilder()
+                .setStatus(Status.Ok)
+                .build();
+    }
+
+    @SneakyThrows
+    private ExecuteResponse processDelete(Operation operation, boolean observe) {
         final OperationDelete delete = operation.getDelete();
         final String key = delete.getKey();
         if (operation.hasPrecondition()) {
@@ -385,6 +645,12 @@ public class OxiaEngine implements Engine {
 
         oxiaClient.delete(key).join();
 
+        if (observe) {
+            final var responseBuilder = ExecuteResponse.newBuilder().setStatus(Status.Ok);
+            observeNotification(operation, responseBuilder);
+            return responseBuilder.build();
+        }
+
         if (operation.hasAssertion()) {
             final Assertion assertion = operation.getAssertion();
             if (assertion.hasNotification()) {
@@ -393,19 +659,13 @@ public class OxiaEngine implements Engine {
                 final String expectKey = notification.getKey();
                 final String keyPrefix = expectKey != null ? expectKey.substring(0, expectKey.indexOf('/', 1) + 1) : null;
 
-                final Notification actualNotification = pollMatchingNotification(keyPrefix, 3, TimeUnit.MINUTES);
+                final Notification actualNotification = pollMatchingNotification(keyPrefix, notificationTimeoutMillis(assertion), TimeUnit.MILLISECONDS);
                 if (actualNotification instanceof Notification.KeyDeleted an) {
                     if (expectType != NotificationType.KEY_DELETED || !expectKey.equals(an.key())) {
-                        return ExecuteResponse.newBuilder()
-                                .setStatus(Status.AssertionFailure)
-                                .setStatusInfo("mismatched notification.")
-                                .build();
+                        return assertionFailure(operation, "mismatched notification.", actualNotification);
                     }
                 } else {
-                    return ExecuteResponse.newBuilder()
-                            .setStatus(Status.AssertionFailure)
-                            .setStatusInfo("mismatched notification.")
-                            .build();
+                    return assertionFailure(operation, "mismatched notification.", actualNotification);
                 }
             }
         }
@@ -415,7 +675,7 @@ public class OxiaEngine implements Engine {
     }
 
     @SneakyThrows
-    private ExecuteResponse processDeleteRange(Operation operation) {
+    private ExecuteResponse processDeleteRange(Operation operation, boolean observe) {
         final OperationDeleteRange deleteRange = operation.getDeleteRange();
         final String keyStart = deleteRange.getKeyStart();
         final String keyEnd = deleteRange.getKeyEnd();
@@ -429,6 +689,12 @@ public class OxiaEngine implements Engine {
 
         oxiaClient.deleteRange(keyStart, keyEnd).join();
 
+        if (observe) {
+            final var responseBuilder = ExecuteResponse.newBuilder().setStatus(Status.Ok);
+            observeNotification(operation, responseBuilder);
+            return responseBuilder.build();
+        }
+
         if (operation.hasAssertion()) {
             final Assertion assertion = operation.getAssertion();
             if (assertion.hasNotification()) {
@@ -437,29 +703,20 @@ public class OxiaEngine implements Engine {
                 final String notifKeyStart = notification.getKeyStart();
                 final String keyPrefix = notifKeyStart != null ? notifKeyStart.substring(0, notifKeyStart.indexOf('/', 1) + 1) : null;
 
-                final Notification actualNotification = pollMatchingNotification(keyPrefix, 3, TimeUnit.MINUTES);
+                final Notification actualNotification = pollMatchingNotification(keyPrefix, notificationTimeoutMillis(assertion), TimeUnit.MILLISECONDS);
                 if (actualNotification instanceof Notification.KeyRangeDelete an) {
                     if (expectType != NotificationType.KEY_RANGE_DELETED) {
-                        return ExecuteResponse.newBuilder()
-                                .setStatus(Status.AssertionFailure)
-                                .setStatusInfo("mismatched notification.")
-                                .build();
+                        return assertionFailure(operation, "mismatched notification.", actualNotification);
                     }
                     final String notificationKeyStart = notification.getKeyStart();
                     final String notificationKeyEnd = notification.getKeyEnd();
 
                     if (!notificationKeyStart.equals(an.startKeyInclusive()) ||
                             !notificationKeyEnd.equals(an.endKeyExclusive())) {
-                        return ExecuteResponse.newBuilder()
-                                .setStatus(Status.AssertionFailure)
-                                .setStatusInfo("mismatched notification.")
-                                .build();
+                        return assertionFailure(operation, "mismatched notification.", actualNotification);
                     }
                 } else {
-                    return ExecuteResponse.newBuilder()
-                            .setStatus(Status.AssertionFailure)
-                            .setStatusInfo("mismatched notification.")
-                            .build();
+                    return assertionFailure(operation, "mismatched notification.", actualNotification);
                 }
             }
         }
@@ -470,6 +727,130 @@ public class OxiaEngine implements Engine {
     }
 
 
+    private static Record toRecord(GetResult result) {
+        final var record = Record.newBuilder()
+                .setKey(result.key())
+                .setValue(ByteString.copyFrom(result.value()));
+        if (result.version() != null) {
+            record.setVersionId(result.version().versionId());
+        }
+        return record.build();
+    }
+
+    /**
+     * Wait for the notification the assertion expects, if any, and report the one received.
+     */
+    private void observeNotification(Operation operation, ExecuteResponse.Builder responseBuilder) {
+        if (!operation.hasAssertion() || !operation.getAssertion().hasNotification()) {
+            return;
+        }
+        final var expected = operation.getAssertion().getNotification();
+        final String expectKey = expected.hasKey() ? expected.getKey() : expected.getKeyStart();
+        final String keyPrefix = expectKey.substring(0, expectKey.indexOf('/', 1) + 1);
+        final Notification actual = pollMatchingNotification(keyPrefix, notificationTimeoutMillis(operation.getAssertion()), TimeUnit.MILLISECONDS);
+        if (actual != null) {
+            responseBuilder.setNotification(toNotification(actual));
+        }
+    }
+
+    private static io.oxia.okk.proto.v1.Notification toNotification(Notification actual) {
+        final var notification = io.oxia.okk.proto.v1.Notification.newBuilder();
+        if (actual instanceof Notification.KeyCreated an) {
+            notification.setType(NotificationType.KEY_CREATED).setKey(an.key());
+        } else if (actual instanceof Notification.KeyModified an) {
+            notification.setType(NotificationType.KEY_MODIFIED).setKey(an.key());
+        } else if (actual instanceof Notification.KeyDeleted an) {
+            notification.setType(NotificationType.KEY_DELETED).setKey(an.key());
+        } else if (actual instanceof Notification.KeyRangeDelete an) {
+            notification.setType(NotificationType.KEY_RANGE_DELETED)
+                    .setKeyStart(an.startKeyInclusive())
+                    .setKeyEnd(an.endKeyExclusive());
+        }
+        return notification.build();
+    }
+
+    private static List<Record> toKeyRecords(List<String> keys) {
+        return keys.stream().map(key -> Record.newBuilder().setKey(key).build()).toList();
+    }
+
+    private static ExecuteResponse assertionFailure(Operation operation, String info) {
+        return assertionFailure(operation, info, List.of(), null);
+    }
+
+    private static ExecuteResponse assertionFailure(Operation operation, String info, Notification actualNotification) {
+        return assertionFailure(operation, info, List.of(), actualNotification);
+    }
+
+    private static ExecuteResponse assertionFailure(Operation operation, String info, List<Record> actualRecords) {
+        return assertionFailure(operation, info, actualRecords, null);
+    }
+
+    /**
+     * Build an AssertionFailure response carrying what the assertion expected and what was observed.
+     */
+    private static ExecuteResponse assertionFailure(Operation operation, String info,
+                                                    List<Record> actualRecords, Notification actualNotification) {
+        final var detail = AssertionFailureDetail.newBuilder()
+                .setSequence(operation.getSequence())
+                .setMessage(info)
+                .addAllActualRecords(actualRecords)
+                .setOperationTimestamp(operation.getTimestamp())
+                .setObservedTimestamp(TimeUnit.MILLISECONDS.toNanos(System.currentTimeMillis()))
+                .setWorkerId(WORKER_ID);
+        if (operation.hasAssertion()) {
+            final Assertion assertion = operation.getAssertion();
+            detail.addAllExpectedRecords(assertion.getRecordsList());
+            if (assertion.hasNotification()) {
+                detail.setExpectedNotification(assertion.getNotification());
+            }
+            if (assertion.hasExpectedError()) {
+                detail.setExpectedError(assertion.getExpectedError());
+            }
+        }
+        if (actualNotification != null) {
+            detail.setActualNotification(toNotification(actualNotification));
+        }
+        return ExecuteResponse.newBuilder()
+                .setStatus(Status.AssertionFailure)
+                .setStatusInfo(info)
+                .setFailureDetail(detail)
+                .build();
+    }
+
+    /**
+     * Compare the actual value with the expected record, by checksum and length when the
+     * coordinator sent them instead of the full value.
+     */
+    private static boolean matchesValue(Record expectRecord, byte[] actualValue) {
+        if (expectRecord.hasValueChecksum()) {
+            final CRC32C checksum = new CRC32C();
+            checksum.update(actualValue);
+            return actualValue.length == expectRecord.getValueLength()
+                    && (int) checksum.getValue() == expectRecord.getValueChecksum();
+        }
+        return Arrays.equals(expectRecord.getValue().toByteArray(), actualValue);
+    }
+
+    /**
+     * The time to wait for the expected sequence updates, from the eventual window of the assertion if any.
+     */
+    private static long sequenceUpdatesTimeoutMillis(Assertion assertion) {
+        if (assertion.hasEventually() && assertion.getEventually().getTimeoutMillis() > 0) {
+            return assertion.getEventually().getTimeoutMillis();
+        }
+        return TimeUnit.MINUTES.toMillis(1);
+    }
+
+    /**
+     * The time to wait for the expected notification, from the eventual window of the assertion if any.
+     */
+    private static long notificationTimeoutMillis(Assertion assertion) {
+        if (assertion.hasEventually() && assertion.getEventually().getTimeoutMillis() > 0) {
+            return assertion.getEventually().getTimeoutMillis();
+        }
+        return TimeUnit.MINUTES.toMillis(3);
+    }
+
     /**
      * Poll the notification queue, skipping notifications that don't match the given key prefix.
      * This prevents cross-test-case notification pollution when multiple tests share one worker.
@@ -500,18 +881,37 @@ public class OxiaEngine implements Engine {
         return null;
     }
 
+    @Override
+    public DescribeResponse describe() {
+        final String clientVersion = AsyncOxiaClient.class.getPackage().getImplementationVersion();
+        return DescribeResponse.newBuilder()
+                .setClientLibrary("oxia-client-java")
+                .setClientVersion(clientVersion != null ? clientVersion : "unknown")
+                .addAllOperations(OPERATIONS)
+                .addAllAssertions(ASSERTIONS)
+                .addAssertionModes(AssertionMode.WORKER)
+                .addAssertionModes(AssertionMode.COORDINATOR)
+                .build();
+    }
+
     @Override
     public ExecuteResponse onCommand(ExecuteCommand command) {
         final Operation operation = command.getOperation();
+        // in the coordinator mode the observations are reported instead of evaluating the assertion
+        final boolean observe = command.getAssertionMode() == AssertionMode.COORDINATOR;
         try {
+            if (operation.hasAssertion() && operation.getAssertion().hasExpectedError()) {
+                return processExpectedError(operation, observe);
+            }
             return switch (operation.getOperationCase()) {
-                case GET -> processGet(command.getTestcase(), operation);
-                case PUT -> processPut(operation);
-                case LIST -> processList(operation);
-                case SCAN -> processScan(operation);
-                case DELETE -> processDelete(operation);
+                case GET -> processGet(command.getTestcase(), operation, observe);
+                case PUT -> processPut(operation, observe);
+                case LIST -> processList(operation, observe);
+                case SCAN -> processScan(operation, observe);
+                case DELETE -> processDelete(operation, observe);
                 case SESSION_RESTART -> processSessionRestart(operation);
-                case DELETE_RANGE -> processDeleteRange(operation);
+                case DELETE_RANGE -> processDeleteRange(operation, observe);
+                case SEQUENCE_UPDATES -> processSequenceUpdates(operation, observe);
                 case OPERATION_NOT_SET -> {
                     log.error("Unsupported operation. operation={}", operation);
                     yield ExecuteResponse.newBuilder()
@@ -1,5 +1,6 @@
 package io.github.oxia.okk.worker.engine;
 
+import io.oxia.okk.proto.v1.DescribeResponse;
 import io.oxia.okk.proto.v1.ExecuteCommand;
 import io.oxia.okk.proto.v1.ExecuteResponse;
 
@@ -8,4 +9,9 @@ public interface Engine {
     void init();
 
     ExecuteResponse onCommand(ExecuteCommand command);
+
+    /**
+     * Describe the client library and the operations and assertions the engine supports.
+     */
+    DescribeResponse describe();
 }
@@ -3,10 +3,36 @@ package io.github.oxia.okk.worker;
 
 import lombok.Builder;
 
+import java.util.HashMap;
+import java.util.Map;
+
+/**
+ * @param tlsCertFile the server certificate, TLS is enabled when it is set along with tlsKeyFile
+ * @param tlsCaFile   the CA verifying the coordinator certificate, mutual TLS is required when it is set
+ * @param tokenFile   the file holding the bearer token the coordinator must send
+ * @param registryAddress     the coordinator registry the worker registers with, it does not register when unset
+ * @param advertisedEndpoint  the endpoint the coordinator connects to the worker at
+ * @param labels              the labels testcases select the worker by
+ * @param registryTokenFile   the file holding the bearer token sent to the registry
+ * @param registryTlsCaFile   the CA verifying the registry certificate, the registry is plaintext when it is unset
+ */
 @Builder
-public record Options(String engineName) {
+public record Options(String engineName, String tlsCertFile, String tlsKeyFile, String tlsCaFile, String tokenFile,
+                      String registryAddress, String advertisedEndpoint, Map<String, String> labels,
+                      String registryTokenFile, String registryTlsCaFile) {
 
     private static final String ENV_KEY_ENGINE_NAME = "OKK_WORKER_ENGINE_NAME";
+    private static final String ENV_KEY_TLS_CERT_FILE = "OKK_WORKER_TLS_CERT_FILE";
+    private static final String ENV_KEY_TLS_KEY_FILE = "OKK_WORKER_TLS_KEY_FILE";
+    private static final String ENV_KEY_TLS_CA_FILE = "OKK_WORKER_TLS_CA_FILE";
+    private static final String ENV_KEY_TOKEN_FILE = "OKK_WORKER_TOKEN_FILE";
+    private static final String ENV_KEY_REGISTRY_ADDRESS = "OKK_WORKER_REGISTRY_ADDRESS";
+    private static final String ENV_KEY_ADVERTISED_ENDPOINT = "OKK_WORKER_ADVERTISED_ENDPOINT";
+    private static final String ENV_KEY_LABELS = "OKK_WORKER_LABELS";
+    private static final String ENV_KEY_ZONE = "OKK_WORKER_ZONE";
+    private static final String ENV_KEY_OXIA_NAMESPACE = "OKK_WORKER_OXIA_NAMESPACE";
+    private static final String ENV_KEY_REGISTRY_TOKEN_FILE = "OKK_WORKER_REGISTRY_TOKEN_FILE";
+    private static final String ENV_KEY_REGISTRY_TLS_CA_FILE = "OKK_WORKER_REGISTRY_TLS_CA_FILE";
 
     public static Options fromEnv() {
         String engineName = System.getenv(ENV_KEY_ENGINE_NAME);
@@ -14,8 +40,57 @@ public record Options(String engineName) {
             engineName = "oxia";
         }
 
+        String advertisedEndpoint = System.getenv(ENV_KEY_ADVERTISED_ENDPOINT);
+        if (advertisedEndpoint == null || advertisedEndpoint.isEmpty()) {
+            final String hostname = System.getenv("HOSTNAME");
+            advertisedEndpoint = (hostname == null || hostname.isEmpty() ? "localhost" : hostname) + ":" + Worker.PORT;
+        }
+
         return Options.builder()
                 .engineName(engineName)
+                .tlsCertFile(emptyToNull(System.getenv(ENV_KEY_TLS_CERT_FILE)))
+                .tlsKeyFile(emptyToNull(System.getenv(ENV_KEY_TLS_KEY_FILE)))
+                .tlsCaFile(emptyToNull(System.getenv(ENV_KEY_TLS_CA_FILE)))
+                .tokenFile(emptyToNull(System.getenv(ENV_KEY_TOKEN_FILE)))
+                .registryAddress(emptyToNull(System.getenv(ENV_KEY_REGISTRY_ADDRESS)))
+                .advertisedEndpoint(advertisedEndpoint)
+                .labels(labelsFromEnv())
+                .registryTokenFile(emptyToNull(System.getenv(ENV_KEY_REGISTRY_TOKEN_FILE)))
+                .registryTlsCaFile(emptyToNull(System.getenv(ENV_KEY_REGISTRY_TLS_CA_FILE)))
                 .build();
     }
+
+    /**
+     * The language, Oxia namespace and zone labels, overridden by the comma separated key=value pairs of
+     * OKK_WORKER_LABELS.
+     */
+    private static Map<String, String> labelsFromEnv() {
+        final Map<String, String> labels = new HashMap<>();
+        labels.put("language", "java");
+        final String namespace = emptyToNull(System.getenv(ENV_KEY_OXIA_NAMESPACE));
+        labels.put("namespace", namespace == null ? "default" : namespace);
+        final String zone = emptyToNull(System.getenv(ENV_KEY_ZONE));
+        if (zone != null) {
+            labels.put("zone", zone);
+        }
+        final String extra = emptyToNull(System.getenv(ENV_KEY_LABELS));
+        if (extra != null) {
+            for (String pair : extra.split(",")) {
+                final int separator = pair.indexOf('=');
+                if (separator <= 0) {
+                    throw new IllegalArgumentException("invalid label " + pair + ", expected key=value");
+                }
+                labels.put(pair.substring(0, separator).strip(), pair.substring(separator + 1).strip());
+            }
+        }
+        return labels;
+    }
+
+    public boolean tlsEnabled() {
+        return tlsCertFile != null && tlsKeyFile != null;
+    }
+
+    private static String emptyToNull(String value) {
+        return value == null || value.isEmpty() ? null : value;
+    }
 }
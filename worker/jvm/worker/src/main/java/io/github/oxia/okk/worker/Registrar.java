@@ -0,0 +1,131 @@
+package io.github.oxia.okk.worker;
+
+import io.grpc.ChannelCredentials;
+import io.grpc.Grpc;
+import io.grpc.InsecureChannelCredentials;
+import io.grpc.ManagedChannel;
+import io.grpc.Metadata;
+import io.grpc.Status;
+import io.grpc.StatusRuntimeException;
+import io.grpc.TlsChannelCredentials;
+import io.grpc.stub.MetadataUtils;
+import io.oxia.okk.proto.v1.DeregisterRequest;
+import io.oxia.okk.proto.v1.HeartbeatRequest;
+import io.oxia.okk.proto.v1.OkkRegistryGrpc;
+import io.oxia.okk.proto.v1.RegisterRequest;
+import io.oxia.okk.proto.v1.RegisterResponse;
+import lombok.SneakyThrows;
+import lombok.extern.slf4j.Slf4j;
+
+import java.io.File;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.concurrent.Executors;
+import java.util.concurrent.ScheduledExecutorService;
+import java.util.concurrent.TimeUnit;
+
+/**
+ * Register the worker with the coordinator registry and keep it registered with heartbeats, so that testcases
+ * can select it by label. The worker registers again whenever the coordinator no longer knows it, e.g. after a
+ * coordinator restart.
+ */
+@Slf4j
+public final class Registrar implements AutoCloseable {
+    private static final Metadata.Key<String> AUTHORIZATION =
+            Metadata.Key.of("authorization", Metadata.ASCII_STRING_MARSHALLER);
+    private static final long RPC_TIMEOUT_MILLIS = 5_000;
+    private static final long RETRY_INTERVAL_MILLIS = 5_000;
+
+    private final Options options;
+    private final ManagedChannel channel;
+    private final OkkRegistryGrpc.OkkRegistryBlockingStub stub;
+    private final ScheduledExecutorService executor = Executors.newSingleThreadScheduledExecutor(runnable -> {
+        final Thread thread = new Thread(runnable, "okk-registrar");
+        thread.setDaemon(true);
+        return thread;
+    });
+
+    private volatile String workerId;
+    private long heartbeatIntervalMillis;
+
+    @SneakyThrows
+    public Registrar(Options options) {
+        this.options = options;
+        this.channel = Grpc.newChannelBuilder(options.registryAddress(), channelCredentials()).build();
+        OkkRegistryGrpc.OkkRegistryBlockingStub stub = OkkRegistryGrpc.newBlockingStub(channel);
+        if (options.registryTokenFile() != null) {
+            final Metadata headers = new Metadata();
+            headers.put(AUTHORIZATION, "Bearer " + Files.readString(Path.of(options.registryTokenFile())).strip());
+            stub = stub.withInterceptors(MetadataUtils.newAttachHeadersInterceptor(headers));
+        }
+        this.stub = stub;
+    }
+
+    public void start() {
+        executor.execute(this::register);
+    }
+
+    private void register() {
+        try {
+            final RegisterResponse response = stub.withDeadlineAfter(RPC_TIMEOUT_MILLIS, TimeUnit.MILLISECONDS)
+                    .register(RegisterRequest.newBuilder()
+                            .setEndpoint(options.advertisedEndpoint())
+                            .putAllLabels(options.labels())
+                            .build());
+            workerId = response.getWorkerId();
+            heartbeatIntervalMillis = response.getHeartbeatIntervalMillis();
+            log.info("Registered with the coordinator. registry: {} endpoint: {} labels: {} worker-id: {}",
+                    options.registryAddress(), options.advertisedEndpoint(), options.labels(), workerId);
+            executor.schedule(this::heartbeat, heartbeatIntervalMillis, TimeUnit.MILLISECONDS);
+        } catch (StatusRuntimeException ex) {
+            log.warn("Failed to register with the coordinator, retrying. registry: {} status: {}",
+                    options.registryAddress(), ex.getStatus());
+            executor.schedule(this::register, RETRY_INTERVAL_MILLIS, TimeUnit.MILLISECONDS);
+        }
+    }
+
+    private void heartbeat() {
+        final long startNanos = System.nanoTime();
+        try {
+            stub.withDeadlineAfter(RPC_TIMEOUT_MILLIS, TimeUnit.MILLISECONDS)
+                    .heartbeat(HeartbeatRequest.newBuilder().setWorkerId(workerId).build());
+        } catch (StatusRuntimeException ex) {
+            if (ex.getStatus().getCode() == Status.Code.NOT_FOUND) {
+                log.warn("The coordinator no longer knows the worker, registering again. worker-id: {}", workerId);
+                workerId = null;
+                register();
+                return;
+            }
+            log.warn("Failed to heartbeat. registry: {} status: {}", options.registryAddress(), ex.getStatus());
+        }
+        // the interval counts from the previous heartbeat, so that a slow one does not delay the next
+        executor.schedule(this::heartbeat, heartbeatIntervalMillis
+                - TimeUnit.NANOSECONDS.toMillis(System.nanoTime() - startNanos), TimeUnit.MILLISECONDS);
+    }
+
+    @Override
+    public void close() {
+        executor.shutdownNow();
+        final String id = workerId;
+        if (id != null) {
+            try {
+                stub.withDeadlineAfter(RPC_TIMEOUT_MILLIS, TimeUnit.MILLISECONDS)
+                        .deregister(DeregisterRequest.newBuilder().setWorkerId(id).build());
+                log.info("Deregistered from the coordinator. worker-id: {}", id);
+            } catch (StatusRuntimeException ex) {
+                log.warn("Failed to deregister from the coordinator. worker-id: {} status: {}", id, ex.getStatus());
+            }
+        }
+        channel.shutdownNow();
+    }
+
+    @SneakyThrows
+    private ChannelCredentials channelCredentials() {
+        if (options.registryTlsCaFile() == null) {
+            return InsecureChannelCredentials.create();
+        }
+        return TlsChannelCredentials.newBuilder()
+                .trustManager(new File(options.registryTlsCaFile()))
+                .build();
+    }
+}
@@ -0,0 +1,49 @@
+package io.github.oxia.okk.worker;
+
+import io.grpc.Metadata;
+import io.grpc.ServerCall;
+import io.grpc.ServerCallHandler;
+import io.grpc.ServerInterceptor;
+import io.grpc.Status;
+import lombok.SneakyThrows;
+import lombok.extern.slf4j.Slf4j;
+
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.security.MessageDigest;
+
+/**
+ * Reject the calls that do not carry the bearer token held by the token file. The file is read on each call
+ * so that the token can be rotated.
+ */
+@Slf4j
+public final class TokenAuthInterceptor implements ServerInterceptor {
+    private static final Metadata.Key<String> AUTHORIZATION =
+            Metadata.Key.of("authorization", Metadata.ASCII_STRING_MARSHALLER);
+    private static final String BEARER_PREFIX = "Bearer ";
+
+    private final Path tokenFile;
+
+    public TokenAuthInterceptor(String tokenFile) {
+        this.tokenFile = Path.of(tokenFile);
+    }
+
+    @SneakyThrows
+    @Override
+    public <ReqT, RespT> ServerCall.Listener<ReqT> interceptCall(ServerCall<ReqT, RespT> call, Metadata headers,
+                                                                 ServerCallHandler<ReqT, RespT> next) {
+        final String expected = Files.readString(tokenFile).strip();
+        final String authorization = headers.get(AUTHORIZATION);
+        if (expected.isEmpty() || authorization == null || !authorization.startsWith(BEARER_PREFIX)
+                || !MessageDigest.isEqual(expected.getBytes(StandardCharsets.UTF_8),
+                authorization.substring(BEARER_PREFIX.length()).getBytes(StandardCharsets.UTF_8))) {
+            log.warn("Rejected a call with a missing or invalid bearer token. method={}",
+                    call.getMethodDescriptor().getFullMethodName());
+            call.close(Status.UNAUTHENTICATED.withDescription("invalid bearer token"), new Metadata());
+            return new ServerCall.Listener<>() {
+            };
+        }
+        return next.startCall(call, headers);
+    }
+}
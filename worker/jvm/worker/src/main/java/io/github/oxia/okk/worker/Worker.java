@@ -2,13 +2,22 @@ package io.github.oxia.okk.worker;
 
 
 import io.github.oxia.okk.worker.engine.Engine;
+import io.grpc.Grpc;
+import io.grpc.InsecureServerCredentials;
 import io.grpc.Server;
 import io.grpc.ServerBuilder;
+import io.grpc.ServerCredentials;
+import io.grpc.TlsServerCredentials;
 import lombok.SneakyThrows;
 import lombok.extern.slf4j.Slf4j;
 
+import java.io.File;
+
 @Slf4j
 public final class Worker implements Runnable {
+    static final int PORT = 6666;
+    private static final int MAX_INBOUND_MESSAGE_SIZE = 64 * 1024 * 1024;
+
     private final Options options;
 
     public Worker(Options options) {
@@ -23,12 +32,23 @@ public final class Worker implements Runnable {
         final Engine engine = EngineFactory.loadEngine(options.engineName());
         engine.init();
 
-        final Server server = ServerBuilder.forPort(6666)
-                .addService(new WorkerService(engine))
-                .build()
-                .start();
-        log.info("GRPC server has been started. port: {}", 6666);
+        final ServerBuilder<?> serverBuilder = Grpc.newServerBuilderForPort(PORT, serverCredentials())
+                // large value workloads send multi-megabyte puts
+                .maxInboundMessageSize(MAX_INBOUND_MESSAGE_SIZE)
+                .addService(new WorkerService(engine));
+        if (options.tokenFile() != null) {
+            serverBuilder.intercept(new TokenAuthInterceptor(options.tokenFile()));
+        }
+        final Server server = serverBuilder.build().start();
+        log.info("GRPC server has been started. port: {}", PORT);
+        final Registrar registrar = options.registryAddress() != null ? new Registrar(options) : null;
+        if (registrar != null) {
+            registrar.start();
+        }
         Runtime.getRuntime().addShutdownHook(new Thread(() -> {
+            if (registrar != null) {
+                registrar.close();
+            }
             log.info("Shutting down gRPC server since JVM is shutting down");
             try {
                 server.shutdown().awaitTermination();
@@ -39,4 +59,22 @@ public final class Worker implements Runnable {
         }));
         server.awaitTermination();
     }
+
+    @SneakyThrows
+    private ServerCredentials serverCredentials() {
+        if (!options.tlsEnabled()) {
+            if (options.tokenFile() != null) {
+                throw new IllegalArgumentException("a bearer token requires TLS");
+            }
+            return InsecureServerCredentials.create();
+        }
+        final var builder = TlsServerCredentials.newBuilder()
+                .keyManager(new File(options.tlsCertFile()), new File(options.tlsKeyFile()));
+        if (options.tlsCaFile() != null) {
+            // mutual TLS, the coordinator must present a certificate signed by the CA
+            builder.trustManager(new File(options.tlsCaFile()))
+                    .clientAuth(TlsServerCredentials.ClientAuth.REQUIRE);
+        }
+        return builder.build();
+    }
 }
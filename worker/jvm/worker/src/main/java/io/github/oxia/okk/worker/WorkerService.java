@@ -1,12 +1,14 @@
 package io.github.oxia.okk.worker;
 
 import com.google.common.base.Throwables;
-import com.google.protobuf.util.JsonFormat;
 import io.github.oxia.okk.worker.engine.Engine;
 import io.grpc.stub.StreamObserver;
+import io.oxia.okk.proto.v1.DescribeRequest;
+import io.oxia.okk.proto.v1.DescribeResponse;
 import io.oxia.okk.proto.v1.ExecuteCommand;
 import io.oxia.okk.proto.v1.ExecuteResponse;
 import io.oxia.okk.proto.v1.OkkGrpc;
+import io.oxia.okk.proto.v1.Operation;
 import lombok.extern.slf4j.Slf4j;
 
 import java.util.concurrent.CompletableFuture;
@@ -15,12 +17,24 @@ import static java.util.concurrent.CompletableFuture.*;
 
 @Slf4j
 public class WorkerService extends OkkGrpc.OkkImplBase {
+    private static final String IMPLEMENTATION = "jvm";
+    private static final int PROTOCOL_VERSION = 1;
+
     private final Engine engine;
 
     public WorkerService(Engine engine) {
         this.engine = engine;
     }
 
+    @Override
+    public void describe(DescribeRequest request, StreamObserver<DescribeResponse> responseObserver) {
+        responseObserver.onNext(engine.describe().toBuilder()
+                .setImplementation(IMPLEMENTATION)
+                .setProtocolVersion(PROTOCOL_VERSION)
+                .build());
+        responseObserver.onCompleted();
+    }
+
     @Override
     public StreamObserver<ExecuteCommand> execute(StreamObserver<ExecuteResponse> responseObserver) {
         log.info("Open stream.");
@@ -30,11 +44,18 @@ public class WorkerService extends OkkGrpc.OkkImplBase {
             public void onNext(ExecuteCommand command) {
                 runAsync(() -> {
                     try {
-                        log.info("Received command: {}", JsonFormat.printer()
-                                .omittingInsignificantWhitespace()
-                                .print(command));
+                        if (log.isDebugEnabled()) {
+                            // the values are left out, they can be several MiB
+                            final Operation operation = command.getOperation();
+                            log.debug("Received command. testcase: {} sequence: {} operation: {} key: {} value-length: {}",
+                                    command.getTestcase(), operation.getSequence(), operation.getOperationCase(),
+                                    key(operation), operation.hasPut() ? operation.getPut().getValue().size() : 0);
+                        }
                         // avoid call it in the grpc thread
-                        final ExecuteResponse executeResponse = engine.onCommand(command);
+                        // echo the sequence so the coordinator can correlate the response with its operation
+                        final ExecuteResponse executeResponse = engine.onCommand(command).toBuilder()
+                                .setSequence(command.getOperation().getSequence())
+                                .build();
                         responseObserver.onNext(executeResponse);
                     } catch (Throwable ex) {
                         log.error("Stream has been closed due to an unexpected error when processing the command.");
@@ -56,4 +77,20 @@ public class WorkerService extends OkkGrpc.OkkImplBase {
             }
         };
     }
+
+    /**
+     * Returns the key, or the first key of the range, an operation targets.
+     */
+    private static String key(Operation operation) {
+        return switch (operation.getOperationCase()) {
+            case PUT -> operation.getPut().getKey();
+            case DELETE -> operation.getDelete().getKey();
+            case GET -> operation.getGet().getKey();
+            case LIST -> operation.getList().getKeyStart();
+            case SCAN -> operation.getScan().getKeyStart();
+            case DELETE_RANGE -> operation.getDeleteRange().getKeyStart();
+            case SEQUENCE_UPDATES -> operation.getSequenceUpdates().getKey();
+            default -> "";
+        };
+    }
 }